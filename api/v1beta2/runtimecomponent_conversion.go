@@ -0,0 +1,34 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import "sigs.k8s.io/controller-runtime/pkg/conversion"
+
+// v1beta2 is the hub version of the rc.app.stacks API group. Future versions of the API
+// implement conversion.Convertible to and from these types.
+//
+// CRD conversion webhooks only convert between versions of the same group, so custom resources
+// of the former app.stacks/v1beta1 API are migrated by a controller instead.
+
+var _ conversion.Hub = &RuntimeComponent{}
+var _ conversion.Hub = &RuntimeOperation{}
+
+// Hub marks RuntimeComponent v1beta2 as the conversion hub
+func (*RuntimeComponent) Hub() {}
+
+// Hub marks RuntimeOperation v1beta2 as the conversion hub
+func (*RuntimeOperation) Hub() {}
@@ -23,6 +23,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -142,6 +143,25 @@ type RuntimeComponentSpec struct {
 
 	// +operator-sdk:csv:customresourcedefinitions:order=26,type=spec,displayName="Network Policy"
 	NetworkPolicy *RuntimeComponentNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=27,type=spec,displayName="Disruption Budget"
+	DisruptionBudget *RuntimeComponentDisruptionBudget `json:"disruptionBudget,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=28,type=spec,displayName="Vertical Scaling"
+	VerticalScaling *RuntimeComponentVerticalScaling `json:"verticalScaling,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=29,type=spec,displayName="Rollout"
+	Rollout *RuntimeComponentRollout `json:"rollout,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=30,type=spec,displayName="Knative"
+	Knative *RuntimeComponentKnative `json:"knative,omitempty"`
+
+	// Services to bind to. The binding secret of each service is projected into the application container
+	// following the Service Binding Specification.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:order=31,type=spec,displayName="Bindings"
+	Bindings []RuntimeComponentBinding `json:"bindings,omitempty"`
 }
 
 // Define health checks on application container to determine whether it is alive or ready to receive traffic
@@ -196,6 +216,74 @@ type RuntimeComponentAutoScaling struct {
 	// Target average CPU utilization, represented as a percentage of requested CPU, over all the pods.
 	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Target CPU Utilization Percentage",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
 	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// Metrics used to calculate the desired number of pods, in addition to targetCPUUtilizationPercentage. Requires autoscaling/v2 on the cluster.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Metrics"
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+
+	// Scaling behavior of the autoscaler in the scale up and scale down directions. Requires autoscaling/v2 on the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Behavior"
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+
+	// Scale the application with a KEDA ScaledObject instead of a HorizontalPodAutoscaler. Allows minReplicas to be 0.
+	// +operator-sdk:csv:customresourcedefinitions:order=6,type=spec,displayName="KEDA"
+	Keda *RuntimeComponentKeda `json:"keda,omitempty"`
+}
+
+// Configures the KEDA ScaledObject that scales the application on events.
+type RuntimeComponentKeda struct {
+	// Events that activate and scale the application.
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Triggers"
+	Triggers []RuntimeComponentKedaTrigger `json:"triggers"`
+
+	// Interval in seconds to check each trigger on. Defaults to 30 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Polling Interval",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// Period in seconds to wait after the last trigger reported active before scaling the application to minReplicas when it is 0. Defaults to 300 seconds.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Cooldown Period",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+}
+
+// Defines a KEDA scaler.
+type RuntimeComponentKedaTrigger struct {
+	// Type of the scaler, such as kafka, rabbitmq or prometheus.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Type",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Type string `json:"type"`
+
+	// Name of the trigger.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name,omitempty"`
+
+	// Configuration parameters of the scaler.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Metadata"
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Type of target of the metric, either AverageValue, Value or Utilization.
+	// +kubebuilder:validation:Enum=AverageValue;Value;Utilization
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Metric Type",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+
+	// Name of the TriggerAuthentication, or ClusterTriggerAuthentication, holding the credentials of the scaler.
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="Authentication Reference"
+	AuthenticationRef *RuntimeComponentKedaAuthenticationRef `json:"authenticationRef,omitempty"`
+}
+
+// Reference to the credentials of a KEDA scaler.
+type RuntimeComponentKedaAuthenticationRef struct {
+	// Name of the TriggerAuthentication or ClusterTriggerAuthentication.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name"`
+
+	// Kind of the referenced resource. Defaults to TriggerAuthentication.
+	// +kubebuilder:validation:Enum=TriggerAuthentication;ClusterTriggerAuthentication
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Kind",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Kind string `json:"kind,omitempty"`
 }
 
 // Configures parameters for the network service of pods.
@@ -234,6 +322,10 @@ type RuntimeComponentService struct {
 	// +operator-sdk:csv:customresourcedefinitions:order=15,type=spec,displayName="Certificate Secret Reference",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	CertificateSecretRef *string `json:"certificateSecretRef,omitempty"`
 
+	// Parameters of the service certificate requested from cert-manager when spec.manageTLS is enabled.
+	// +operator-sdk:csv:customresourcedefinitions:order=18,type=spec,displayName="Certificate"
+	Certificate *RuntimeComponentServiceCertificate `json:"certificate,omitempty"`
+
 	// An array consisting of service ports.
 	// +operator-sdk:csv:customresourcedefinitions:order=16,type=spec
 	Ports []corev1.ServicePort `json:"ports,omitempty"`
@@ -241,6 +333,47 @@ type RuntimeComponentService struct {
 	// Expose the application as a bindable service. Defaults to false.
 	// +operator-sdk:csv:customresourcedefinitions:order=17,type=spec,displayName="Bindable",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	Bindable *bool `json:"bindable,omitempty"`
+
+	// Enable mutual TLS with the other components of the application. The component gets a client certificate from the CA issuer
+	// managed by the operator, and the trust bundle of the namespace. Requires spec.manageTLS. Defaults to false.
+	// +operator-sdk:csv:customresourcedefinitions:order=19,type=spec,displayName="Mutual TLS",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	MTLS *bool `json:"mtls,omitempty"`
+}
+
+// Defines the parameters of the service certificate requested from cert-manager.
+type RuntimeComponentServiceCertificate struct {
+	// Issuer of the certificate. Defaults to the issuer set in the operator configuration, or to a CA issuer managed by the operator in the namespace.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Issuer Reference"
+	IssuerRef *RuntimeComponentIssuerRef `json:"issuerRef,omitempty"`
+
+	// Requested lifetime of the certificate. Defaults to the duration set in the operator configuration.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Duration",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// How long before the expiry of the certificate cert-manager renews it. Defaults to the value set in the operator configuration.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Renew Before",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Private key of the certificate.
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Private Key"
+	PrivateKey *RuntimeComponentCertificatePrivateKey `json:"privateKey,omitempty"`
+
+	// DNS names to add to the certificate, besides the DNS names of the Service.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=5,type=spec,displayName="DNS Names"
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// Defines the private key of a certificate.
+type RuntimeComponentCertificatePrivateKey struct {
+	// Algorithm of the private key. Defaults to RSA.
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Algorithm",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Size of the private key in bits. Can be 2048, 4096 or 8192 for RSA, defaulting to 2048, and 256, 384 or 521 for ECDSA, defaulting to 256. Not used for Ed25519.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Size",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Size int `json:"size,omitempty"`
 }
 
 // Defines the network policy
@@ -258,6 +391,188 @@ type RuntimeComponentNetworkPolicy struct {
 	FromLabels *map[string]string `json:"fromLabels,omitempty"`
 }
 
+// Defines the PodDisruptionBudget of the application pods.
+type RuntimeComponentDisruptionBudget struct {
+	// Minimum number or percentage of pods that must remain available during a voluntary disruption. Cannot be set together with maxUnavailable.
+	// +operator-sdk:csv:customresourcedefinitions:order=49,type=spec,displayName="Min Available",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Maximum number or percentage of pods that can be unavailable during a voluntary disruption. Defaults to 1 if minAvailable is not set.
+	// +operator-sdk:csv:customresourcedefinitions:order=50,type=spec,displayName="Max Unavailable",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// Configures a VerticalPodAutoscaler that recommends, and optionally sets, the resource requests of the application container.
+type RuntimeComponentVerticalScaling struct {
+	// How the recommendations are applied. Off only reports them in status, Initial sets them when pods are created and Auto also updates running pods. Defaults to Off.
+	// +kubebuilder:validation:Enum=Off;Initial;Auto
+	// +operator-sdk:csv:customresourcedefinitions:order=51,type=spec,displayName="Update Mode",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:Off","urn:alm:descriptor:com.tectonic.ui:select:Initial","urn:alm:descriptor:com.tectonic.ui:select:Auto"
+	UpdateMode *string `json:"updateMode,omitempty"`
+
+	// Resources to compute recommendations for. Defaults to cpu and memory.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=52,type=spec,displayName="Controlled Resources"
+	ControlledResources []corev1.ResourceName `json:"controlledResources,omitempty"`
+
+	// Lower limit for the recommended resources.
+	// +operator-sdk:csv:customresourcedefinitions:order=53,type=spec,displayName="Min Allowed"
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// Upper limit for the recommended resources.
+	// +operator-sdk:csv:customresourcedefinitions:order=54,type=spec,displayName="Max Allowed"
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// Rolls out a new application image to a canary Deployment and shifts traffic to it before replacing the stable Deployment.
+type RuntimeComponentRollout struct {
+	// Canary shifts traffic to the new image step by step. BlueGreen deploys the new image at full scale without traffic, then switches all traffic at once. Defaults to Canary.
+	// +kubebuilder:validation:Enum=Canary;BlueGreen
+	// +operator-sdk:csv:customresourcedefinitions:order=55,type=spec,displayName="Strategy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:Canary","urn:alm:descriptor:com.tectonic.ui:select:BlueGreen"
+	Strategy *string `json:"strategy,omitempty"`
+
+	// Traffic steps of the Canary strategy. Required for the Canary strategy.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=56,type=spec,displayName="Steps"
+	Steps []RuntimeComponentRolloutStep `json:"steps,omitempty"`
+
+	// Number of pods of the canary Deployment for the Canary strategy. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=57,type=spec,displayName="Canary Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	CanaryReplicas *int32 `json:"canaryReplicas,omitempty"`
+
+	// Seconds to keep the new image ready, without traffic, before switching traffic to it with the BlueGreen strategy. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=58,type=spec,displayName="Auto Promotion Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	AutoPromotionSeconds *int32 `json:"autoPromotionSeconds,omitempty"`
+
+	// Seconds for the new image to become ready in a step before the rollout is aborted. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=59,type=spec,displayName="Progress Deadline Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// Abort the rollout in progress and send all traffic back to the stable Deployment.
+	// +operator-sdk:csv:customresourcedefinitions:order=60,type=spec,displayName="Abort",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Abort *bool `json:"abort,omitempty"`
+}
+
+// Defines a traffic step of a Canary rollout.
+type RuntimeComponentRolloutStep struct {
+	// Percentage of the traffic sent to the canary Deployment.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Weight",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Weight int32 `json:"weight"`
+
+	// Seconds to stay in the step before moving to the next step. The canary Deployment must also be ready. The next step starts as soon as the canary Deployment is ready when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Pause Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	PauseSeconds *int32 `json:"pauseSeconds,omitempty"`
+}
+
+// Configures the Knative Service created when createKnativeService is true.
+type RuntimeComponentKnative struct {
+	// Distribution of the traffic across the revisions of the Knative Service. Sends all traffic to the latest ready revision when not set.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=61,type=spec,displayName="Traffic"
+	Traffic []RuntimeComponentKnativeTraffic `json:"traffic,omitempty"`
+
+	// Maximum number of requests that a single instance processes at the same time. Defaults to 0, which does not limit the number of requests.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:order=62,type=spec,displayName="Container Concurrency",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ContainerConcurrency *int64 `json:"containerConcurrency,omitempty"`
+
+	// Maximum duration in seconds that the application is allowed to respond to a request. Defaults to the Knative configuration, 300 seconds by default.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=63,type=spec,displayName="Timeout Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// Configures the Knative autoscaler. The number of instances is bounded by spec.autoscaling.minReplicas and spec.autoscaling.maxReplicas.
+	// +operator-sdk:csv:customresourcedefinitions:order=64,type=spec,displayName="Autoscaling"
+	Autoscaling *RuntimeComponentKnativeAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// Configures the autoscaler of the Knative Service revisions.
+type RuntimeComponentKnativeAutoscaling struct {
+	// Autoscaler implementation. kpa scales on concurrency or rps and can scale to zero, hpa scales on cpu or memory.
+	// Defaults to hpa when the metric is cpu or memory, and to kpa otherwise. The hpa class needs the HPA extension of Knative.
+	// +kubebuilder:validation:Enum=kpa;hpa
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Class",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:kpa,urn:alm:descriptor:com.tectonic.ui:select:hpa"
+	Class *string `json:"class,omitempty"`
+
+	// Metric to scale on. Defaults to concurrency for kpa and to cpu for hpa.
+	// +kubebuilder:validation:Enum=concurrency;rps;cpu;memory
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Metric",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Metric *string `json:"metric,omitempty"`
+
+	// Target value of the metric for each instance. Defaults to spec.autoscaling.targetCPUUtilizationPercentage for the cpu metric.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Target",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Target *int32 `json:"target,omitempty"`
+}
+
+// Defines a traffic target of the Knative Service.
+type RuntimeComponentKnativeTraffic struct {
+	// Name of the revision to send traffic to. Cannot be set together with latestRevision.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Revision Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RevisionName string `json:"revisionName,omitempty"`
+
+	// Send traffic to the latest ready revision. Cannot be set together with revisionName.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Latest Revision",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	LatestRevision *bool `json:"latestRevision,omitempty"`
+
+	// Percentage of the traffic sent to the target. The percentages of all targets must add up to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Percent",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	Percent *int64 `json:"percent,omitempty"`
+
+	// Tag of the target. Knative gives tagged targets their own URL, reported in status.endpoints, to reach them directly.
+	// +operator-sdk:csv:customresourcedefinitions:order=4,type=spec,displayName="Tag",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Tag string `json:"tag,omitempty"`
+}
+
+// Reports the progress of a canary or blue/green rollout.
+type StatusRollout struct {
+	// Phase of the rollout.
+	Phase RolloutPhase `json:"phase,omitempty"`
+	// Image of the stable Deployment.
+	StableImage string `json:"stableImage,omitempty"`
+	// Image of the canary Deployment.
+	CanaryImage string `json:"canaryImage,omitempty"`
+	// Index of the current step.
+	Step int32 `json:"step,omitempty"`
+	// Time at which the current step started.
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+	// Percentage of the traffic sent to the canary Deployment.
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+	// Human readable details about the phase.
+	Message string `json:"message,omitempty"`
+}
+
+// Defines the phase of a rollout.
+type RolloutPhase string
+
+const (
+	// The stable Deployment runs the desired image
+	RolloutPhaseCompleted RolloutPhase = "Completed"
+	// The canary Deployment runs the new image and receives the traffic of the current step
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// The canary Deployment receives all traffic while the stable Deployment is updated to the new image
+	RolloutPhasePromoting RolloutPhase = "Promoting"
+	// The new image was abandoned and the stable Deployment receives all traffic
+	RolloutPhaseAborted RolloutPhase = "Aborted"
+)
+
+// Reports the resources recommended by the VerticalPodAutoscaler for the application container.
+type StatusResourceRecommendations struct {
+	// Recommended resources.
+	Target corev1.ResourceList `json:"target,omitempty"`
+	// Minimum recommended resources.
+	LowerBound corev1.ResourceList `json:"lowerBound,omitempty"`
+	// Maximum recommended resources.
+	UpperBound corev1.ResourceList `json:"upperBound,omitempty"`
+}
+
 // Defines the desired state and cycle of applications.
 type RuntimeComponentDeployment struct {
 
@@ -341,6 +656,10 @@ type RuntimeComponentRoute struct {
 	// +operator-sdk:csv:customresourcedefinitions:order=41,type=spec,displayName="Certificate Secret Reference",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	CertificateSecretRef *string `json:"certificateSecretRef,omitempty"`
 
+	// Certificate to request from cert-manager for the hosts of the route. Cannot be set together with certificateSecretRef.
+	// +operator-sdk:csv:customresourcedefinitions:order=47,type=spec,displayName="Certificate"
+	Certificate *RuntimeComponentRouteCertificate `json:"certificate,omitempty"`
+
 	// TLS termination policy. Can be one of edge, reencrypt and passthrough.
 	// +operator-sdk:csv:customresourcedefinitions:order=42,type=spec,displayName="Termination",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	Termination *routev1.TLSTerminationType `json:"termination,omitempty"`
@@ -348,6 +667,123 @@ type RuntimeComponentRoute struct {
 	// HTTP traffic policy with TLS enabled. Can be one of Allow, Redirect and None.
 	// +operator-sdk:csv:customresourcedefinitions:order=43,type=spec,displayName="Insecure Edge Termination Policy",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	InsecureEdgeTerminationPolicy *routev1.InsecureEdgeTerminationPolicyType `json:"insecureEdgeTerminationPolicy,omitempty"`
+
+	// Additional hosts and paths to expose, besides host and path. Each entry can send traffic to a different port of the Service.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=44,type=spec,displayName="Additional Paths"
+	AdditionalPaths []RuntimeComponentRoutePath `json:"additionalPaths,omitempty"`
+
+	// Name of the IngressClass of the Ingress. Defaults to the default IngressClass of the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:order=45,type=spec,displayName="Ingress Class Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Gateway to attach a Gateway API HTTPRoute, or a TLSRoute for passthrough termination, to instead of creating a Route or an Ingress.
+	// Defaults to the gateway set in the operator configuration.
+	// +operator-sdk:csv:customresourcedefinitions:order=46,type=spec,displayName="Gateway"
+	Gateway *RuntimeComponentGateway `json:"gateway,omitempty"`
+}
+
+// Defines an additional host and path that expose a port of the application.
+type RuntimeComponentRoutePath struct {
+	// Hostname of the path. Defaults to the host of the route.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Host",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Host string `json:"host,omitempty"`
+
+	// Path to expose.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Path",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Path string `json:"path,omitempty"`
+
+	// Path type to be used for Ingress. Defaults to the path type of the route.
+	PathType networkingv1.PathType `json:"pathType,omitempty"`
+
+	// Number or name of the Service port to send the traffic to. Defaults to the primary port.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Port",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Port *intstr.IntOrString `json:"port,omitempty"`
+}
+
+// Defines a certificate requested from cert-manager for the hosts of the route.
+type RuntimeComponentRouteCertificate struct {
+	// Issuer of the certificate.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Issuer Reference"
+	IssuerRef RuntimeComponentIssuerRef `json:"issuerRef"`
+
+	// Requested lifetime of the certificate. Defaults to the lifetime set by cert-manager.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Duration",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// DNS names to add to the certificate, besides the hosts of the route.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="DNS Names"
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// Reference to a cert-manager Issuer or ClusterIssuer.
+type RuntimeComponentIssuerRef struct {
+	// Name of the Issuer or ClusterIssuer.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name"`
+
+	// Kind of the referenced issuer. Defaults to ClusterIssuer.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Kind",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Kind string `json:"kind,omitempty"`
+}
+
+// Defines the parent Gateway of the Gateway API routes that expose the application.
+type RuntimeComponentGateway struct {
+	// Name of the Gateway.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the namespace of the application.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Namespace",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the Gateway listener to attach to. Defaults to all the listeners that allow the route.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Section Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// Defines a service binding that projects the binding secret of a service into the application container.
+type RuntimeComponentBinding struct {
+	// Name of the binding, used as the name of its directory under $SERVICE_BINDING_ROOT.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name"`
+
+	// Service to bind to, in the namespace of the application.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Service"
+	Service RuntimeComponentBindingService `json:"service"`
+
+	// Environment variables set from entries of the binding secret.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Environment Variables"
+	Env []RuntimeComponentBindingEnv `json:"env,omitempty"`
+}
+
+// Defines a reference to a RuntimeComponent, a Provisioned Service that reports its binding secret in status.binding.name,
+// or a binding Secret.
+type RuntimeComponentBindingService struct {
+	// API version of the service. Defaults to rc.app.stacks/v1beta2.
+	// +operator-sdk:csv:customresourcedefinitions:order=1,type=spec,displayName="API Version",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the service. Defaults to RuntimeComponent.
+	// +operator-sdk:csv:customresourcedefinitions:order=2,type=spec,displayName="Kind",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the service.
+	// +operator-sdk:csv:customresourcedefinitions:order=3,type=spec,displayName="Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name"`
+}
+
+// Defines an environment variable set from an entry of a binding secret.
+type RuntimeComponentBindingEnv struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Key of the entry in the binding secret.
+	Key string `json:"key"`
 }
 
 // Defines the observed state of RuntimeComponent.
@@ -363,6 +799,38 @@ type RuntimeComponentStatus struct {
 	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
 
 	References common.StatusReferences `json:"references,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Resource Recommendations"
+	ResourceRecommendations *StatusResourceRecommendations `json:"resourceRecommendations,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Rollout"
+	Rollout *StatusRollout `json:"rollout,omitempty"`
+
+	// Names of the components of the same application that have mutual TLS enabled and trust the same CA as this component.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Trusted Peers"
+	TrustedPeers []string `json:"trustedPeers,omitempty"`
+
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Certificates"
+	Certificates []StatusCertificate `json:"certificates,omitempty"`
+
+	// Binding secrets of the services in spec.bindings that are projected into the application container.
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Bindings"
+	Bindings []StatusBinding `json:"bindings,omitempty"`
+
+	// Number of pods of the Deployment or StatefulSet of the application.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Number of ready pods of the Deployment or StatefulSet of the application.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Ready Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Label selector of the pods of the application, used by the scale subresource.
+	Selector string `json:"selector,omitempty"`
 }
 
 // Defines possible status conditions.
@@ -385,14 +853,40 @@ type StatusEndpoint struct {
 	URI   string              `json:"uri,omitempty"`
 }
 
+// Reports the state of a certificate used by the application.
+type StatusCertificate struct {
+	// Use of the certificate: Service, Route or Client.
+	Name string `json:"name"`
+	// Name of the secret with the certificate.
+	SecretName string `json:"secretName,omitempty"`
+	// Issuer of the certificate, from its tls.crt.
+	Issuer string `json:"issuer,omitempty"`
+	// Expiry time of the certificate, from its tls.crt.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// Whether the certificate is issued and valid.
+	Ready bool `json:"ready"`
+	// Reason why the certificate is not ready, or expires soon.
+	Message string `json:"message,omitempty"`
+}
+
+// Reports the binding secret of a service binding.
+type StatusBinding struct {
+	// Name of the binding in spec.bindings.
+	Name string `json:"name"`
+	// Name of the binding secret of the service.
+	SecretName string `json:"secretName"`
+}
+
 // Defines the scope of endpoint information in status.
 type StatusEndpointScope string
 
 const (
 	// Status Condition Types
-	StatusConditionTypeReconciled     StatusConditionType = "Reconciled"
-	StatusConditionTypeResourcesReady StatusConditionType = "ResourcesReady"
-	StatusConditionTypeReady          StatusConditionType = "Ready"
+	StatusConditionTypeReconciled        StatusConditionType = "Reconciled"
+	StatusConditionTypeResourcesReady    StatusConditionType = "ResourcesReady"
+	StatusConditionTypeReady             StatusConditionType = "Ready"
+	StatusConditionTypeCertificatesReady StatusConditionType = "CertificatesReady"
+	StatusConditionTypeReplicasIgnored   StatusConditionType = "ReplicasIgnored"
 
 	// Status Endpoint Scopes
 	StatusEndpointScopeExternal StatusEndpointScope = "External"
@@ -402,6 +896,7 @@ const (
 // +kubebuilder:resource:path=runtimecomponents,scope=Namespaced,shortName=comp;comps
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 // +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.applicationImage",priority=0,description="Absolute name of the deployed image containing registry and tag"
 // +kubebuilder:printcolumn:name="Exposed",type="boolean",JSONPath=".spec.expose",priority=0,description="Specifies whether deployment is exposed externally via default Route"
 // +kubebuilder:printcolumn:name="Reconciled",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].status",priority=0,description="Status of the reconcile condition"
@@ -414,7 +909,7 @@ const (
 // +kubebuilder:printcolumn:name="ReadyReason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",priority=1,description="Reason for the failure of component ready condition"
 // +kubebuilder:printcolumn:name="ReadyMessage",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].message",priority=1,description="Failure message from component ready condition"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",priority=0,description="Age of the resource"
-// +operator-sdk:csv:customresourcedefinitions:displayName="RuntimeComponent",resources={{Deployment,v1},{Service,v1},{StatefulSet,v1},{Route,v1},{HorizontalPodAutoscaler,v1},{ServiceAccount,v1},{Secret,v1},{NetworkPolicy,v1}}
+// +operator-sdk:csv:customresourcedefinitions:displayName="RuntimeComponent",resources={{Deployment,v1},{Service,v1},{StatefulSet,v1},{Route,v1},{HorizontalPodAutoscaler,v2},{ServiceAccount,v1},{Secret,v1},{NetworkPolicy,v1},{PodDisruptionBudget,v1},{ScaledObject,v1alpha1}}
 
 // Represents the deployment of a runtime component
 type RuntimeComponent struct {
@@ -535,6 +1030,129 @@ func (cr *RuntimeComponent) GetCreateKnativeService() *bool {
 	return cr.Spec.CreateKnativeService
 }
 
+// GetKnative returns Knative Service settings
+func (cr *RuntimeComponent) GetKnative() common.BaseComponentKnative {
+	if cr.Spec.Knative == nil {
+		return nil
+	}
+	return cr.Spec.Knative
+}
+
+// GetBindings returns the services to bind to
+func (cr *RuntimeComponent) GetBindings() []common.BaseComponentBinding {
+	var bindings = make([]common.BaseComponentBinding, len(cr.Spec.Bindings))
+	for i := range cr.Spec.Bindings {
+		bindings[i] = &cr.Spec.Bindings[i]
+	}
+	return bindings
+}
+
+// GetName returns the name of the binding
+func (b *RuntimeComponentBinding) GetName() string {
+	return b.Name
+}
+
+// GetService returns the service to bind to
+func (b *RuntimeComponentBinding) GetService() common.BaseComponentBindingService {
+	return &b.Service
+}
+
+// GetEnv returns the environment variables set from entries of the binding secret
+func (b *RuntimeComponentBinding) GetEnv() []common.BaseComponentBindingEnv {
+	var env = make([]common.BaseComponentBindingEnv, len(b.Env))
+	for i := range b.Env {
+		env[i] = &b.Env[i]
+	}
+	return env
+}
+
+// GetAPIVersion returns the API version of the service
+func (s *RuntimeComponentBindingService) GetAPIVersion() string {
+	return s.APIVersion
+}
+
+// GetKind returns the kind of the service
+func (s *RuntimeComponentBindingService) GetKind() string {
+	return s.Kind
+}
+
+// GetName returns the name of the service
+func (s *RuntimeComponentBindingService) GetName() string {
+	return s.Name
+}
+
+// GetName returns the name of the environment variable
+func (e *RuntimeComponentBindingEnv) GetName() string {
+	return e.Name
+}
+
+// GetKey returns the key of the entry in the binding secret
+func (e *RuntimeComponentBindingEnv) GetKey() string {
+	return e.Key
+}
+
+// GetTraffic returns the traffic targets of the Knative Service
+func (k *RuntimeComponentKnative) GetTraffic() []common.BaseComponentKnativeTraffic {
+	var traffic = make([]common.BaseComponentKnativeTraffic, len(k.Traffic))
+	for i := range k.Traffic {
+		traffic[i] = &k.Traffic[i]
+	}
+	return traffic
+}
+
+// GetContainerConcurrency returns the maximum number of requests that an instance processes at the same time
+func (k *RuntimeComponentKnative) GetContainerConcurrency() *int64 {
+	return k.ContainerConcurrency
+}
+
+// GetTimeoutSeconds returns the maximum duration to respond to a request
+func (k *RuntimeComponentKnative) GetTimeoutSeconds() *int64 {
+	return k.TimeoutSeconds
+}
+
+// GetAutoscaling returns the Knative autoscaler settings
+func (k *RuntimeComponentKnative) GetAutoscaling() common.BaseComponentKnativeAutoscaling {
+	if k.Autoscaling == nil {
+		return nil
+	}
+	return k.Autoscaling
+}
+
+// GetClass returns the autoscaler implementation
+func (a *RuntimeComponentKnativeAutoscaling) GetClass() *string {
+	return a.Class
+}
+
+// GetMetric returns the metric to scale on
+func (a *RuntimeComponentKnativeAutoscaling) GetMetric() *string {
+	return a.Metric
+}
+
+// GetTarget returns the target value of the metric
+func (a *RuntimeComponentKnativeAutoscaling) GetTarget() *int32 {
+	return a.Target
+}
+
+// GetRevisionName returns the name of the revision to send traffic to
+func (t *RuntimeComponentKnativeTraffic) GetRevisionName() string {
+	return t.RevisionName
+}
+
+// GetLatestRevision returns whether traffic is sent to the latest ready revision
+func (t *RuntimeComponentKnativeTraffic) GetLatestRevision() *bool {
+	return t.LatestRevision
+}
+
+// GetPercent returns the percentage of the traffic sent to the target
+func (t *RuntimeComponentKnativeTraffic) GetPercent() *int64 {
+	return t.Percent
+}
+
+// GetTag returns the tag of the target
+func (t *RuntimeComponentKnativeTraffic) GetTag() string {
+	return t.Tag
+}
+
 // GetAutoscaling returns autoscaling settings
 func (cr *RuntimeComponent) GetAutoscaling() common.BaseComponentAutoscaling {
 	if cr.Spec.Autoscaling == nil {
@@ -563,6 +1181,122 @@ func (cr *RuntimeComponent) GetNetworkPolicy() common.BaseComponentNetworkPolicy
 	return cr.Spec.NetworkPolicy
 }
 
+// GetDisruptionBudget returns PodDisruptionBudget settings
+func (cr *RuntimeComponent) GetDisruptionBudget() common.BaseComponentDisruptionBudget {
+	if cr.Spec.DisruptionBudget == nil {
+		return nil
+	}
+	return cr.Spec.DisruptionBudget
+}
+
+// GetMinAvailable returns the minimum number or percentage of available pods
+func (db *RuntimeComponentDisruptionBudget) GetMinAvailable() *intstr.IntOrString {
+	return db.MinAvailable
+}
+
+// GetMaxUnavailable returns the maximum number or percentage of unavailable pods
+func (db *RuntimeComponentDisruptionBudget) GetMaxUnavailable() *intstr.IntOrString {
+	return db.MaxUnavailable
+}
+
+// GetVerticalScaling returns VerticalPodAutoscaler settings
+func (cr *RuntimeComponent) GetVerticalScaling() common.BaseComponentVerticalScaling {
+	if cr.Spec.VerticalScaling == nil {
+		return nil
+	}
+	return cr.Spec.VerticalScaling
+}
+
+// GetUpdateMode returns how the recommendations are applied
+func (vs *RuntimeComponentVerticalScaling) GetUpdateMode() string {
+	if vs.UpdateMode == nil {
+		return "Off"
+	}
+	return *vs.UpdateMode
+}
+
+// GetControlledResources returns the resources to compute recommendations for
+func (vs *RuntimeComponentVerticalScaling) GetControlledResources() []corev1.ResourceName {
+	if len(vs.ControlledResources) == 0 {
+		return []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	}
+	return vs.ControlledResources
+}
+
+// GetMinAllowed returns the lower limit for the recommended resources
+func (vs *RuntimeComponentVerticalScaling) GetMinAllowed() corev1.ResourceList {
+	return vs.MinAllowed
+}
+
+// GetMaxAllowed returns the upper limit for the recommended resources
+func (vs *RuntimeComponentVerticalScaling) GetMaxAllowed() corev1.ResourceList {
+	return vs.MaxAllowed
+}
+
+// GetRollout returns canary or blue/green rollout settings
+func (cr *RuntimeComponent) GetRollout() common.BaseComponentRollout {
+	if cr.Spec.Rollout == nil {
+		return nil
+	}
+	return cr.Spec.Rollout
+}
+
+// GetStrategy returns the rollout strategy
+func (ro *RuntimeComponentRollout) GetStrategy() string {
+	if ro.Strategy == nil {
+		return "Canary"
+	}
+	return *ro.Strategy
+}
+
+// GetCanaryReplicas returns the number of pods of the canary Deployment for the Canary strategy
+func (ro *RuntimeComponentRollout) GetCanaryReplicas() int32 {
+	if ro.CanaryReplicas == nil {
+		return 1
+	}
+	return *ro.CanaryReplicas
+}
+
+// GetAutoPromotionSeconds returns the seconds to wait before switching traffic with the BlueGreen strategy
+func (ro *RuntimeComponentRollout) GetAutoPromotionSeconds() int32 {
+	if ro.AutoPromotionSeconds == nil {
+		return 0
+	}
+	return *ro.AutoPromotionSeconds
+}
+
+// GetProgressDeadlineSeconds returns the seconds for the new image to become ready in a step
+func (ro *RuntimeComponentRollout) GetProgressDeadlineSeconds() int32 {
+	if ro.ProgressDeadlineSeconds == nil {
+		return 600
+	}
+	return *ro.ProgressDeadlineSeconds
+}
+
+// GetSteps returns the traffic steps of the Canary strategy
+func (ro *RuntimeComponentRollout) GetSteps() []common.BaseComponentRolloutStep {
+	var steps = make([]common.BaseComponentRolloutStep, len(ro.Steps))
+	for i := range ro.Steps {
+		steps[i] = &ro.Steps[i]
+	}
+	return steps
+}
+
+// GetAbort returns whether the rollout in progress must be aborted
+func (ro *RuntimeComponentRollout) GetAbort() bool {
+	return ro.Abort != nil && *ro.Abort
+}
+
+// GetWeight returns the percentage of the traffic sent to the canary Deployment
+func (st *RuntimeComponentRolloutStep) GetWeight() int32 {
+	return st.Weight
+}
+
+// GetPauseSeconds returns the seconds to stay in the step
+func (st *RuntimeComponentRolloutStep) GetPauseSeconds() *int32 {
+	return st.PauseSeconds
+}
+
 // GetApplicationVersion returns application version
 func (cr *RuntimeComponent) GetApplicationVersion() string {
 	return cr.Spec.ApplicationVersion
@@ -678,6 +1412,23 @@ func (s *RuntimeComponentStatus) SetBinding(r *corev1.LocalObjectReference) {
 	s.Binding = r
 }
 
+// GetResourceRecommendations returns the resources recommended by the VerticalPodAutoscaler
+func (s *RuntimeComponentStatus) GetResourceRecommendations() (target, lowerBound, upperBound corev1.ResourceList) {
+	if s.ResourceRecommendations == nil {
+		return nil, nil, nil
+	}
+	return s.ResourceRecommendations.Target, s.ResourceRecommendations.LowerBound, s.ResourceRecommendations.UpperBound
+}
+
+// SetResourceRecommendations sets the resources recommended by the VerticalPodAutoscaler, or clears them when none are given
+func (s *RuntimeComponentStatus) SetResourceRecommendations(target, lowerBound, upperBound corev1.ResourceList) {
+	if len(target) == 0 && len(lowerBound) == 0 && len(upperBound) == 0 {
+		s.ResourceRecommendations = nil
+		return
+	}
+	s.ResourceRecommendations = &StatusResourceRecommendations{Target: target, LowerBound: lowerBound, UpperBound: upperBound}
+}
+
 // GetMinReplicas returns minimum replicas
 func (a *RuntimeComponentAutoScaling) GetMinReplicas() *int32 {
 	return a.MinReplicas
@@ -693,6 +1444,81 @@ func (a *RuntimeComponentAutoScaling) GetTargetCPUUtilizationPercentage() *int32
 	return a.TargetCPUUtilizationPercentage
 }
 
+// GetMetrics returns the metrics used to calculate the desired number of pods
+func (a *RuntimeComponentAutoScaling) GetMetrics() []autoscalingv2.MetricSpec {
+	return a.Metrics
+}
+
+// GetBehavior returns the scaling behavior of the autoscaler
+func (a *RuntimeComponentAutoScaling) GetBehavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return a.Behavior
+}
+
+// GetKeda returns the KEDA ScaledObject configuration
+func (a *RuntimeComponentAutoScaling) GetKeda() common.BaseComponentKeda {
+	if a.Keda == nil {
+		return nil
+	}
+	return a.Keda
+}
+
+// GetTriggers returns the events that scale the application
+func (k *RuntimeComponentKeda) GetTriggers() []common.BaseComponentKedaTrigger {
+	var triggers = make([]common.BaseComponentKedaTrigger, len(k.Triggers))
+	for i := range k.Triggers {
+		triggers[i] = &k.Triggers[i]
+	}
+	return triggers
+}
+
+// GetPollingInterval returns the interval to check each trigger on
+func (k *RuntimeComponentKeda) GetPollingInterval() *int32 {
+	return k.PollingInterval
+}
+
+// GetCooldownPeriod returns the period to wait before scaling the application to zero
+func (k *RuntimeComponentKeda) GetCooldownPeriod() *int32 {
+	return k.CooldownPeriod
+}
+
+// GetType returns the type of the scaler
+func (t *RuntimeComponentKedaTrigger) GetType() string {
+	return t.Type
+}
+
+// GetName returns the name of the trigger
+func (t *RuntimeComponentKedaTrigger) GetName() string {
+	return t.Name
+}
+
+// GetMetadata returns the configuration parameters of the scaler
+func (t *RuntimeComponentKedaTrigger) GetMetadata() map[string]string {
+	return t.Metadata
+}
+
+// GetMetricType returns the type of target of the metric
+func (t *RuntimeComponentKedaTrigger) GetMetricType() autoscalingv2.MetricTargetType {
+	return t.MetricType
+}
+
+// GetAuthenticationRef returns the reference to the credentials of the scaler
+func (t *RuntimeComponentKedaTrigger) GetAuthenticationRef() common.BaseComponentKedaAuthenticationRef {
+	if t.AuthenticationRef == nil {
+		return nil
+	}
+	return t.AuthenticationRef
+}
+
+// GetName returns the name of the TriggerAuthentication
+func (r *RuntimeComponentKedaAuthenticationRef) GetName() string {
+	return r.Name
+}
+
+// GetKind returns the kind of the TriggerAuthentication
+func (r *RuntimeComponentKedaAuthenticationRef) GetKind() string {
+	return r.Kind
+}
+
 // GetSize returns persistent volume size
 func (s *RuntimeComponentStorage) GetSize() string {
 	return s.Size
@@ -761,6 +1587,60 @@ func (s *RuntimeComponentService) GetBindable() *bool {
 	return s.Bindable
 }
 
+// GetMTLS returns whether mutual TLS is enabled with the other components of the application
+func (s *RuntimeComponentService) GetMTLS() *bool {
+	return s.MTLS
+}
+
+// GetCertificate returns the parameters of the service certificate requested from cert-manager
+func (s *RuntimeComponentService) GetCertificate() common.BaseComponentServiceCertificate {
+	if s.Certificate == nil {
+		return nil
+	}
+	return s.Certificate
+}
+
+// GetIssuerRef returns the issuer of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetIssuerRef() common.BaseComponentIssuerRef {
+	if c.IssuerRef == nil {
+		return nil
+	}
+	return c.IssuerRef
+}
+
+// GetDuration returns the requested lifetime of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetDuration() *metav1.Duration {
+	return c.Duration
+}
+
+// GetRenewBefore returns how long before its expiry the service certificate is renewed
+func (c *RuntimeComponentServiceCertificate) GetRenewBefore() *metav1.Duration {
+	return c.RenewBefore
+}
+
+// GetPrivateKey returns the private key parameters of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetPrivateKey() common.BaseComponentCertificatePrivateKey {
+	if c.PrivateKey == nil {
+		return nil
+	}
+	return c.PrivateKey
+}
+
+// GetDNSNames returns the additional DNS names of the service certificate
+func (c *RuntimeComponentServiceCertificate) GetDNSNames() []string {
+	return c.DNSNames
+}
+
+// GetAlgorithm returns the algorithm of the private key
+func (k *RuntimeComponentCertificatePrivateKey) GetAlgorithm() string {
+	return k.Algorithm
+}
+
+// GetSize returns the size of the private key
+func (k *RuntimeComponentCertificatePrivateKey) GetSize() int {
+	return k.Size
+}
+
 func (np *RuntimeComponentNetworkPolicy) GetNamespaceLabels() map[string]string {
 	if np == nil || np.NamespaceLabels == nil {
 		return nil
@@ -824,6 +1704,96 @@ func (r *RuntimeComponentRoute) GetPathType() networkingv1.PathType {
 	return r.PathType
 }
 
+// GetAdditionalPaths returns the additional hosts and paths to expose
+func (r *RuntimeComponentRoute) GetAdditionalPaths() []common.BaseComponentRoutePath {
+	var paths = make([]common.BaseComponentRoutePath, len(r.AdditionalPaths))
+	for i := range r.AdditionalPaths {
+		paths[i] = &r.AdditionalPaths[i]
+	}
+	return paths
+}
+
+// GetIngressClassName returns the name of the IngressClass of the Ingress
+func (r *RuntimeComponentRoute) GetIngressClassName() *string {
+	return r.IngressClassName
+}
+
+// GetHost returns the hostname of the path
+func (p *RuntimeComponentRoutePath) GetHost() string {
+	return p.Host
+}
+
+// GetPath returns the path to expose
+func (p *RuntimeComponentRoutePath) GetPath() string {
+	return p.Path
+}
+
+// GetPathType returns the path type to use for the Ingress
+func (p *RuntimeComponentRoutePath) GetPathType() networkingv1.PathType {
+	return p.PathType
+}
+
+// GetPort returns the Service port to send the traffic to
+func (p *RuntimeComponentRoutePath) GetPort() *intstr.IntOrString {
+	return p.Port
+}
+
+// GetGateway returns the parent Gateway of the Gateway API routes
+func (r *RuntimeComponentRoute) GetGateway() common.BaseComponentGateway {
+	if r.Gateway == nil {
+		return nil
+	}
+	return r.Gateway
+}
+
+// GetCertificate returns the certificate to request from cert-manager for the route
+func (r *RuntimeComponentRoute) GetCertificate() common.BaseComponentRouteCertificate {
+	if r.Certificate == nil {
+		return nil
+	}
+	return r.Certificate
+}
+
+// GetIssuerRef returns the issuer of the certificate
+func (c *RuntimeComponentRouteCertificate) GetIssuerRef() common.BaseComponentIssuerRef {
+	return &c.IssuerRef
+}
+
+// GetDuration returns the requested lifetime of the certificate
+func (c *RuntimeComponentRouteCertificate) GetDuration() *metav1.Duration {
+	return c.Duration
+}
+
+// GetDNSNames returns the additional DNS names of the certificate
+func (c *RuntimeComponentRouteCertificate) GetDNSNames() []string {
+	return c.DNSNames
+}
+
+// GetName returns the name of the issuer
+func (i *RuntimeComponentIssuerRef) GetName() string {
+	return i.Name
+}
+
+// GetKind returns the kind of the issuer
+func (i *RuntimeComponentIssuerRef) GetKind() string {
+	return i.Kind
+}
+
+// GetName returns the name of the Gateway
+func (g *RuntimeComponentGateway) GetName() string {
+	return g.Name
+}
+
+// GetNamespace returns the namespace of the Gateway
+func (g *RuntimeComponentGateway) GetNamespace() string {
+	return g.Namespace
+}
+
+// GetSectionName returns the name of the Gateway listener
+func (g *RuntimeComponentGateway) GetSectionName() string {
+	return g.SectionName
+}
+
 // GetNodeAffinity returns node affinity
 func (a *RuntimeComponentAffinity) GetNodeAffinity() *corev1.NodeAffinity {
 	return a.NodeAffinity
@@ -865,6 +1835,8 @@ func (cr *RuntimeComponent) Initialize() {
 		cr.Spec.Resources = &corev1.ResourceRequirements{}
 	}
 
+	cr.InitializeReplicas()
+
 	// Default applicationName to cr.Name, if a user sets createAppDefinition to true but doesn't set applicationName
 	if cr.Spec.ApplicationName == "" {
 		if cr.Labels != nil && cr.Labels["app.kubernetes.io/part-of"] != "" {
@@ -901,6 +1873,15 @@ func (cr *RuntimeComponent) Initialize() {
 	}
 }
 
+// InitializeReplicas sets spec.replicas to 1 when it is not set, as the scale subresource needs it, unless an
+// autoscaler or Knative manages the number of pods
+func (cr *RuntimeComponent) InitializeReplicas() {
+	if cr.Spec.Replicas == nil && cr.Spec.Autoscaling == nil && (cr.Spec.CreateKnativeService == nil || !*cr.Spec.CreateKnativeService) {
+		replicas := int32(1)
+		cr.Spec.Replicas = &replicas
+	}
+}
+
 // GetLabels returns set of labels to be added to all resources
 func (cr *RuntimeComponent) GetLabels() map[string]string {
 	labels := map[string]string{
@@ -1062,6 +2043,112 @@ func (s *RuntimeComponentStatus) SetReference(name string, value string) {
 	s.References[name] = value
 }
 
+// GetCertificates returns the state of the certificates used by the application
+func (s *RuntimeComponentStatus) GetCertificates() []common.StatusCertificate {
+	certificates := make([]common.StatusCertificate, len(s.Certificates))
+	for i := range s.Certificates {
+		certificates[i] = &s.Certificates[i]
+	}
+	return certificates
+}
+
+// NewStatusCertificate returns the state of a certificate
+func (s *RuntimeComponentStatus) NewStatusCertificate(name string, secretName string, issuer string, notAfter *metav1.Time, ready bool, message string) common.StatusCertificate {
+	return &StatusCertificate{Name: name, SecretName: secretName, Issuer: issuer, NotAfter: notAfter, Ready: ready, Message: message}
+}
+
+// SetCertificates sets the state of the certificates used by the application
+func (s *RuntimeComponentStatus) SetCertificates(certificates []common.StatusCertificate) {
+	s.Certificates = nil
+	for _, c := range certificates {
+		s.Certificates = append(s.Certificates, *c.(*StatusCertificate))
+	}
+}
+
+// GetReplicas returns the number of pods of the application
+func (s *RuntimeComponentStatus) GetReplicas() int32 {
+	return s.Replicas
+}
+
+// GetReadyReplicas returns the number of ready pods of the application
+func (s *RuntimeComponentStatus) GetReadyReplicas() int32 {
+	return s.ReadyReplicas
+}
+
+// GetSelector returns the label selector of the pods of the application
+func (s *RuntimeComponentStatus) GetSelector() string {
+	return s.Selector
+}
+
+// SetReplicas sets the number of pods and ready pods of the application, and the label selector of the pods
+func (s *RuntimeComponentStatus) SetReplicas(replicas int32, readyReplicas int32, selector string) {
+	s.Replicas = replicas
+	s.ReadyReplicas = readyReplicas
+	s.Selector = selector
+}
+
+// GetBindings returns the binding secrets of the service bindings
+func (s *RuntimeComponentStatus) GetBindings() []common.StatusBinding {
+	bindings := make([]common.StatusBinding, len(s.Bindings))
+	for i := range s.Bindings {
+		bindings[i] = &s.Bindings[i]
+	}
+	return bindings
+}
+
+// NewStatusBinding returns the binding secret of a service binding
+func (s *RuntimeComponentStatus) NewStatusBinding(name string, secretName string) common.StatusBinding {
+	return &StatusBinding{Name: name, SecretName: secretName}
+}
+
+// SetBindings sets the binding secrets of the service bindings
+func (s *RuntimeComponentStatus) SetBindings(bindings []common.StatusBinding) {
+	s.Bindings = nil
+	for _, b := range bindings {
+		s.Bindings = append(s.Bindings, *b.(*StatusBinding))
+	}
+}
+
+// GetBindingName returns the name of the service binding
+func (b *StatusBinding) GetBindingName() string {
+	return b.Name
+}
+
+// GetSecretName returns the name of the binding secret
+func (b *StatusBinding) GetSecretName() string {
+	return b.SecretName
+}
+
+// GetCertificateName returns the use of the certificate
+func (c *StatusCertificate) GetCertificateName() string {
+	return c.Name
+}
+
+// GetSecretName returns the name of the secret with the certificate
+func (c *StatusCertificate) GetSecretName() string {
+	return c.SecretName
+}
+
+// GetIssuer returns the issuer of the certificate
+func (c *StatusCertificate) GetIssuer() string {
+	return c.Issuer
+}
+
+// GetNotAfter returns the expiry time of the certificate
+func (c *StatusCertificate) GetNotAfter() *metav1.Time {
+	return c.NotAfter
+}
+
+// IsReady returns whether the certificate is issued and valid
+func (c *StatusCertificate) IsReady() bool {
+	return c.Ready
+}
+
+// GetMessage returns why the certificate is not ready, or expires soon
+func (c *StatusCertificate) GetMessage() string {
+	return c.Message
+}
+
 func convertToCommonStatusConditionType(c StatusConditionType) common.StatusConditionType {
 	switch c {
 	case StatusConditionTypeReconciled:
@@ -1070,6 +2157,10 @@ func convertToCommonStatusConditionType(c StatusConditionType) common.StatusCond
 		return common.StatusConditionTypeResourcesReady
 	case StatusConditionTypeReady:
 		return common.StatusConditionTypeReady
+	case StatusConditionTypeCertificatesReady:
+		return common.StatusConditionTypeCertificatesReady
+	case StatusConditionTypeReplicasIgnored:
+		return common.StatusConditionTypeReplicasIgnored
 	default:
 		panic(c)
 	}
@@ -1083,6 +2174,10 @@ func convertFromCommonStatusConditionType(c common.StatusConditionType) StatusCo
 		return StatusConditionTypeResourcesReady
 	case common.StatusConditionTypeReady:
 		return StatusConditionTypeReady
+	case common.StatusConditionTypeCertificatesReady:
+		return StatusConditionTypeCertificatesReady
+	case common.StatusConditionTypeReplicasIgnored:
+		return StatusConditionTypeReplicasIgnored
 	default:
 		panic(c)
 	}
@@ -1147,6 +2242,15 @@ func (s *RuntimeComponentStatus) RemoveStatusEndpoint(endpointName string) {
 	}
 }
 
+// GetStatusEndpoints returns all the endpoints in status
+func (s *RuntimeComponentStatus) GetStatusEndpoints() []common.StatusEndpoint {
+	var endpoints = make([]common.StatusEndpoint, len(s.Endpoints))
+	for i := range s.Endpoints {
+		endpoints[i] = &s.Endpoints[i]
+	}
+	return endpoints
+}
+
 // NewStatusEndpoint returns new endpoint information
 func (s *RuntimeComponentStatus) NewStatusEndpoint(endpointName string) common.StatusEndpoint {
 	e := &StatusEndpoint{}
@@ -26,8 +26,11 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -132,6 +135,23 @@ func (in *RuntimeComponentAutoScaling) DeepCopyInto(out *RuntimeComponentAutoSca
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keda != nil {
+		in, out := &in.Keda, &out.Keda
+		*out = new(RuntimeComponentKeda)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentAutoScaling.
@@ -144,6 +164,72 @@ func (in *RuntimeComponentAutoScaling) DeepCopy() *RuntimeComponentAutoScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentBinding) DeepCopyInto(out *RuntimeComponentBinding) {
+	*out = *in
+	out.Service = in.Service
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]RuntimeComponentBindingEnv, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentBinding.
+func (in *RuntimeComponentBinding) DeepCopy() *RuntimeComponentBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentBindingEnv) DeepCopyInto(out *RuntimeComponentBindingEnv) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentBindingEnv.
+func (in *RuntimeComponentBindingEnv) DeepCopy() *RuntimeComponentBindingEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentBindingEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentBindingService) DeepCopyInto(out *RuntimeComponentBindingService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentBindingService.
+func (in *RuntimeComponentBindingService) DeepCopy() *RuntimeComponentBindingService {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentBindingService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentCertificatePrivateKey) DeepCopyInto(out *RuntimeComponentCertificatePrivateKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentCertificatePrivateKey.
+func (in *RuntimeComponentCertificatePrivateKey) DeepCopy() *RuntimeComponentCertificatePrivateKey {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentCertificatePrivateKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeComponentDeployment) DeepCopyInto(out *RuntimeComponentDeployment) {
 	*out = *in
@@ -171,6 +257,227 @@ func (in *RuntimeComponentDeployment) DeepCopy() *RuntimeComponentDeployment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentDisruptionBudget) DeepCopyInto(out *RuntimeComponentDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentDisruptionBudget.
+func (in *RuntimeComponentDisruptionBudget) DeepCopy() *RuntimeComponentDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentGateway) DeepCopyInto(out *RuntimeComponentGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentGateway.
+func (in *RuntimeComponentGateway) DeepCopy() *RuntimeComponentGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentIssuerRef) DeepCopyInto(out *RuntimeComponentIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentIssuerRef.
+func (in *RuntimeComponentIssuerRef) DeepCopy() *RuntimeComponentIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentKeda) DeepCopyInto(out *RuntimeComponentKeda) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]RuntimeComponentKedaTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentKeda.
+func (in *RuntimeComponentKeda) DeepCopy() *RuntimeComponentKeda {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentKeda)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentKedaAuthenticationRef) DeepCopyInto(out *RuntimeComponentKedaAuthenticationRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentKedaAuthenticationRef.
+func (in *RuntimeComponentKedaAuthenticationRef) DeepCopy() *RuntimeComponentKedaAuthenticationRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentKedaAuthenticationRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentKedaTrigger) DeepCopyInto(out *RuntimeComponentKedaTrigger) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AuthenticationRef != nil {
+		in, out := &in.AuthenticationRef, &out.AuthenticationRef
+		*out = new(RuntimeComponentKedaAuthenticationRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentKedaTrigger.
+func (in *RuntimeComponentKedaTrigger) DeepCopy() *RuntimeComponentKedaTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentKedaTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentKnative) DeepCopyInto(out *RuntimeComponentKnative) {
+	*out = *in
+	if in.Traffic != nil {
+		in, out := &in.Traffic, &out.Traffic
+		*out = make([]RuntimeComponentKnativeTraffic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContainerConcurrency != nil {
+		in, out := &in.ContainerConcurrency, &out.ContainerConcurrency
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(RuntimeComponentKnativeAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentKnative.
+func (in *RuntimeComponentKnative) DeepCopy() *RuntimeComponentKnative {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentKnative)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentKnativeAutoscaling) DeepCopyInto(out *RuntimeComponentKnativeAutoscaling) {
+	*out = *in
+	if in.Class != nil {
+		in, out := &in.Class, &out.Class
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(string)
+		**out = **in
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentKnativeAutoscaling.
+func (in *RuntimeComponentKnativeAutoscaling) DeepCopy() *RuntimeComponentKnativeAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentKnativeAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentKnativeTraffic) DeepCopyInto(out *RuntimeComponentKnativeTraffic) {
+	*out = *in
+	if in.LatestRevision != nil {
+		in, out := &in.LatestRevision, &out.LatestRevision
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentKnativeTraffic.
+func (in *RuntimeComponentKnativeTraffic) DeepCopy() *RuntimeComponentKnativeTraffic {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentKnativeTraffic)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeComponentList) DeepCopyInto(out *RuntimeComponentList) {
 	*out = *in
@@ -304,6 +611,73 @@ func (in *RuntimeComponentProbes) DeepCopy() *RuntimeComponentProbes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentRollout) DeepCopyInto(out *RuntimeComponentRollout) {
+	*out = *in
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RuntimeComponentRolloutStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CanaryReplicas != nil {
+		in, out := &in.CanaryReplicas, &out.CanaryReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AutoPromotionSeconds != nil {
+		in, out := &in.AutoPromotionSeconds, &out.AutoPromotionSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentRollout.
+func (in *RuntimeComponentRollout) DeepCopy() *RuntimeComponentRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentRolloutStep) DeepCopyInto(out *RuntimeComponentRolloutStep) {
+	*out = *in
+	if in.PauseSeconds != nil {
+		in, out := &in.PauseSeconds, &out.PauseSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentRolloutStep.
+func (in *RuntimeComponentRolloutStep) DeepCopy() *RuntimeComponentRolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentRolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeComponentRoute) DeepCopyInto(out *RuntimeComponentRoute) {
 	*out = *in
@@ -319,6 +693,11 @@ func (in *RuntimeComponentRoute) DeepCopyInto(out *RuntimeComponentRoute) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(RuntimeComponentRouteCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Termination != nil {
 		in, out := &in.Termination, &out.Termination
 		*out = new(routev1.TLSTerminationType)
@@ -329,6 +708,23 @@ func (in *RuntimeComponentRoute) DeepCopyInto(out *RuntimeComponentRoute) {
 		*out = new(routev1.InsecureEdgeTerminationPolicyType)
 		**out = **in
 	}
+	if in.AdditionalPaths != nil {
+		in, out := &in.AdditionalPaths, &out.AdditionalPaths
+		*out = make([]RuntimeComponentRoutePath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(RuntimeComponentGateway)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentRoute.
@@ -341,6 +737,52 @@ func (in *RuntimeComponentRoute) DeepCopy() *RuntimeComponentRoute {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentRouteCertificate) DeepCopyInto(out *RuntimeComponentRouteCertificate) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentRouteCertificate.
+func (in *RuntimeComponentRouteCertificate) DeepCopy() *RuntimeComponentRouteCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentRouteCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentRoutePath) DeepCopyInto(out *RuntimeComponentRoutePath) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentRoutePath.
+func (in *RuntimeComponentRoutePath) DeepCopy() *RuntimeComponentRoutePath {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentRoutePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeComponentService) DeepCopyInto(out *RuntimeComponentService) {
 	*out = *in
@@ -371,6 +813,11 @@ func (in *RuntimeComponentService) DeepCopyInto(out *RuntimeComponentService) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(RuntimeComponentServiceCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
 		*out = make([]v1.ServicePort, len(*in))
@@ -383,6 +830,11 @@ func (in *RuntimeComponentService) DeepCopyInto(out *RuntimeComponentService) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentService.
@@ -395,6 +847,46 @@ func (in *RuntimeComponentService) DeepCopy() *RuntimeComponentService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentServiceCertificate) DeepCopyInto(out *RuntimeComponentServiceCertificate) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(RuntimeComponentIssuerRef)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = new(RuntimeComponentCertificatePrivateKey)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentServiceCertificate.
+func (in *RuntimeComponentServiceCertificate) DeepCopy() *RuntimeComponentServiceCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentServiceCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeComponentSpec) DeepCopyInto(out *RuntimeComponentSpec) {
 	*out = *in
@@ -530,6 +1022,33 @@ func (in *RuntimeComponentSpec) DeepCopyInto(out *RuntimeComponentSpec) {
 		*out = new(RuntimeComponentNetworkPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(RuntimeComponentDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerticalScaling != nil {
+		in, out := &in.VerticalScaling, &out.VerticalScaling
+		*out = new(RuntimeComponentVerticalScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RuntimeComponentRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Knative != nil {
+		in, out := &in.Knative, &out.Knative
+		*out = new(RuntimeComponentKnative)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]RuntimeComponentBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentSpec.
@@ -601,6 +1120,33 @@ func (in *RuntimeComponentStatus) DeepCopyInto(out *RuntimeComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = new(StatusResourceRecommendations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(StatusRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedPeers != nil {
+		in, out := &in.TrustedPeers, &out.TrustedPeers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]StatusCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]StatusBinding, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentStatus.
@@ -633,6 +1179,45 @@ func (in *RuntimeComponentStorage) DeepCopy() *RuntimeComponentStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeComponentVerticalScaling) DeepCopyInto(out *RuntimeComponentVerticalScaling) {
+	*out = *in
+	if in.UpdateMode != nil {
+		in, out := &in.UpdateMode, &out.UpdateMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ControlledResources != nil {
+		in, out := &in.ControlledResources, &out.ControlledResources
+		*out = make([]v1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeComponentVerticalScaling.
+func (in *RuntimeComponentVerticalScaling) DeepCopy() *RuntimeComponentVerticalScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeComponentVerticalScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuntimeOperation) DeepCopyInto(out *RuntimeOperation) {
 	*out = *in
@@ -734,6 +1319,40 @@ func (in *RuntimeOperationStatus) DeepCopy() *RuntimeOperationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusBinding) DeepCopyInto(out *StatusBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusBinding.
+func (in *StatusBinding) DeepCopy() *StatusBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCertificate) DeepCopyInto(out *StatusCertificate) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCertificate.
+func (in *StatusCertificate) DeepCopy() *StatusCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusCondition) DeepCopyInto(out *StatusCondition) {
 	*out = *in
@@ -767,3 +1386,58 @@ func (in *StatusEndpoint) DeepCopy() *StatusEndpoint {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusResourceRecommendations) DeepCopyInto(out *StatusResourceRecommendations) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.LowerBound != nil {
+		in, out := &in.LowerBound, &out.LowerBound
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.UpperBound != nil {
+		in, out := &in.UpperBound, &out.UpperBound
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusResourceRecommendations.
+func (in *StatusResourceRecommendations) DeepCopy() *StatusResourceRecommendations {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusResourceRecommendations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusRollout) DeepCopyInto(out *StatusRollout) {
+	*out = *in
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusRollout.
+func (in *StatusRollout) DeepCopy() *StatusRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusRollout)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,7 +1,13 @@
 package common
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // OpConfig stored operator configuration
@@ -17,21 +23,28 @@ const (
 
 	// OpConfigCMCADuration default duration for cert-manager issued service certificate
 	OpConfigCMCertDuration = "certManagerCertDuration"
-)
 
-// Config stores operator configuration
-var Config = OpConfig{}
+	// OpConfigCMCertRenewBefore default time before expiry at which cert-manager renews service certificates
+	OpConfigCMCertRenewBefore = "certManagerCertRenewBefore"
 
-// LoadFromConfigMap creates a config out of kubernetes config map
-func (oc OpConfig) LoadFromConfigMap(cm *corev1.ConfigMap) {
-	for k, v := range DefaultOpConfig() {
-		oc[k] = v
-	}
+	// OpConfigCMIssuerName name of the cert-manager issuer of service certificates, instead of the CA issuer managed by the operator
+	OpConfigCMIssuerName = "certManagerIssuerName"
 
-	for k, v := range cm.Data {
-		oc[k] = v
-	}
-}
+	// OpConfigCMIssuerKind kind of the issuer set by OpConfigCMIssuerName, Issuer or ClusterIssuer
+	OpConfigCMIssuerKind = "certManagerIssuerKind"
+
+	// OpConfigCertExpiryWarningDays number of days before the expiry of a certificate at which a warning event is emitted
+	OpConfigCertExpiryWarningDays = "certificateExpiryWarningDays"
+
+	// OpConfigServerSideApply enables server-side apply for the resources generated by the operator
+	OpConfigServerSideApply = "serverSideApply"
+
+	// OpConfigGatewayName name of the Gateway that exposes applications with Gateway API routes by default
+	OpConfigGatewayName = "gatewayName"
+
+	// OpConfigGatewayNamespace namespace of the default Gateway
+	OpConfigGatewayNamespace = "gatewayNamespace"
+)
 
 // DefaultOpConfig returns default configuration
 func DefaultOpConfig() OpConfig {
@@ -39,5 +52,128 @@ func DefaultOpConfig() OpConfig {
 	cfg[OpConfigDefaultHostname] = ""
 	cfg[OpConfigCMCADuration] = "8766h"
 	cfg[OpConfigCMCertDuration] = "2160h"
+	cfg[OpConfigCMCertRenewBefore] = ""
+	cfg[OpConfigCMIssuerName] = ""
+	cfg[OpConfigCMIssuerKind] = "ClusterIssuer"
+	cfg[OpConfigCertExpiryWarningDays] = "14"
+	cfg[OpConfigServerSideApply] = "false"
+	cfg[OpConfigGatewayName] = ""
+	cfg[OpConfigGatewayNamespace] = ""
+	return cfg
+}
+
+// OperatorConfig is the validated configuration of the operator
+type OperatorConfig struct {
+	DefaultHostname string
+	CACertDuration  time.Duration
+	CertDuration    time.Duration
+	// CertRenewBefore is zero when cert-manager renews the certificates at its default time
+	CertRenewBefore       time.Duration
+	IssuerName            string
+	IssuerKind            string
+	CertExpiryWarningDays int
+	ServerSideApply       bool
+	GatewayName           string
+	GatewayNamespace      string
+}
+
+// ParseOpConfig returns the configuration in the data of the operator ConfigMap, with the defaults for the missing keys.
+// It returns an error that lists all the invalid values.
+func ParseOpConfig(data map[string]string) (*OperatorConfig, error) {
+	values := DefaultOpConfig()
+	for k, v := range data {
+		values[k] = v
+	}
+
+	invalid := []string{}
+	parseDuration := func(key string) time.Duration {
+		if values[key] == "" && key == OpConfigCMCertRenewBefore {
+			return 0
+		}
+		d, err := time.ParseDuration(values[key])
+		if err != nil || d <= 0 {
+			invalid = append(invalid, fmt.Sprintf("%s: %q is not a positive duration", key, values[key]))
+		}
+		return d
+	}
+	checkName := func(key string, isValid func(string) []string) string {
+		if values[key] != "" {
+			if errs := isValid(values[key]); len(errs) > 0 {
+				invalid = append(invalid, fmt.Sprintf("%s: %q is not valid: %s", key, values[key], strings.Join(errs, ", ")))
+			}
+		}
+		return values[key]
+	}
+
+	cfg := &OperatorConfig{
+		DefaultHostname:  checkName(OpConfigDefaultHostname, validation.IsDNS1123Subdomain),
+		CACertDuration:   parseDuration(OpConfigCMCADuration),
+		CertDuration:     parseDuration(OpConfigCMCertDuration),
+		CertRenewBefore:  parseDuration(OpConfigCMCertRenewBefore),
+		IssuerName:       checkName(OpConfigCMIssuerName, validation.IsDNS1123Subdomain),
+		IssuerKind:       values[OpConfigCMIssuerKind],
+		GatewayName:      checkName(OpConfigGatewayName, validation.IsDNS1123Subdomain),
+		GatewayNamespace: checkName(OpConfigGatewayNamespace, validation.IsDNS1123Label),
+	}
+	if cfg.CertRenewBefore >= cfg.CertDuration && cfg.CertDuration > 0 {
+		invalid = append(invalid, fmt.Sprintf("%s: %q is not shorter than %s", OpConfigCMCertRenewBefore, values[OpConfigCMCertRenewBefore], OpConfigCMCertDuration))
+	}
+	switch cfg.IssuerKind {
+	case "":
+		cfg.IssuerKind = "ClusterIssuer"
+	case "Issuer", "ClusterIssuer":
+	default:
+		invalid = append(invalid, fmt.Sprintf("%s: %q is not Issuer or ClusterIssuer", OpConfigCMIssuerKind, cfg.IssuerKind))
+	}
+	days, err := strconv.Atoi(values[OpConfigCertExpiryWarningDays])
+	if err != nil || days < 0 {
+		invalid = append(invalid, fmt.Sprintf("%s: %q is not a non-negative number", OpConfigCertExpiryWarningDays, values[OpConfigCertExpiryWarningDays]))
+	}
+	cfg.CertExpiryWarningDays = days
+	if cfg.ServerSideApply, err = strconv.ParseBool(values[OpConfigServerSideApply]); err != nil {
+		invalid = append(invalid, fmt.Sprintf("%s: %q is not true or false", OpConfigServerSideApply, values[OpConfigServerSideApply]))
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid operator configuration: %s", strings.Join(invalid, "; "))
+	}
+	return cfg, nil
+}
+
+// ChangedKeys returns the keys of the values that differ in the other configuration
+func (c *OperatorConfig) ChangedKeys(other *OperatorConfig) []string {
+	changed := []string{}
+	add := func(key string, isChanged bool) {
+		if isChanged {
+			changed = append(changed, key)
+		}
+	}
+	add(OpConfigDefaultHostname, c.DefaultHostname != other.DefaultHostname)
+	add(OpConfigCMCADuration, c.CACertDuration != other.CACertDuration)
+	add(OpConfigCMCertDuration, c.CertDuration != other.CertDuration)
+	add(OpConfigCMCertRenewBefore, c.CertRenewBefore != other.CertRenewBefore)
+	add(OpConfigCMIssuerName, c.IssuerName != other.IssuerName)
+	add(OpConfigCMIssuerKind, c.IssuerKind != other.IssuerKind)
+	add(OpConfigCertExpiryWarningDays, c.CertExpiryWarningDays != other.CertExpiryWarningDays)
+	add(OpConfigServerSideApply, c.ServerSideApply != other.ServerSideApply)
+	add(OpConfigGatewayName, c.GatewayName != other.GatewayName)
+	add(OpConfigGatewayNamespace, c.GatewayNamespace != other.GatewayNamespace)
+	return changed
+}
+
+// config is the current configuration of the operator, which is replaced as a whole when the operator ConfigMap changes
+var config atomic.Value
+
+// GetConfig returns the current configuration of the operator. The configuration is shared and must not be modified.
+func GetConfig() *OperatorConfig {
+	if cfg, ok := config.Load().(*OperatorConfig); ok && cfg != nil {
+		return cfg
+	}
+	cfg, _ := ParseOpConfig(nil)
 	return cfg
 }
+
+// SetConfig replaces the configuration of the operator. A nil configuration restores the defaults.
+func SetConfig(cfg *OperatorConfig) {
+	config.Store(cfg)
+}
@@ -4,9 +4,11 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // StatusConditionType ...
@@ -18,9 +20,13 @@ type StatusEndpointScope string
 type StatusReferences map[string]string
 
 const (
-	StatusReferenceCertSecretName    = "svcCertSecretName"
-	StatusReferencePullSecretName    = "saPullSecretName"
-	StatusReferenceSAResourceVersion = "saResourceVersion"
+	StatusReferenceCertSecretName       = "svcCertSecretName"
+	StatusReferenceRouteCertSecretName  = "routeCertSecretName"
+	StatusReferenceClientCertSecretName = "clientCertSecretName"
+	StatusReferenceTrustBundleName      = "trustBundleName"
+	StatusReferencePullSecretName       = "saPullSecretName"
+	StatusReferenceSAResourceVersion    = "saResourceVersion"
+	StatusReferenceGatewayProtocol      = "gatewayProtocol"
 )
 
 // StatusCondition ...
@@ -60,6 +66,22 @@ type StatusEndpoint interface {
 	SetStatusEndpointFields(StatusEndpointScope, string, string) StatusEndpoint
 }
 
+// StatusCertificate represents the state of a certificate used by the application
+type StatusCertificate interface {
+	GetCertificateName() string
+	GetSecretName() string
+	GetIssuer() string
+	GetNotAfter() *metav1.Time
+	IsReady() bool
+	GetMessage() string
+}
+
+// StatusBinding represents the binding secret of a service binding
+type StatusBinding interface {
+	GetBindingName() string
+	GetSecretName() string
+}
+
 // BaseComponentStatus returns base appplication status
 type BaseComponentStatus interface {
 	GetConditions() []StatusCondition
@@ -67,6 +89,7 @@ type BaseComponentStatus interface {
 	SetCondition(StatusCondition)
 	NewCondition(StatusConditionType) StatusCondition
 
+	GetStatusEndpoints() []StatusEndpoint
 	GetStatusEndpoint(string) StatusEndpoint
 	SetStatusEndpoint(StatusEndpoint)
 	NewStatusEndpoint(string) StatusEndpoint
@@ -78,16 +101,34 @@ type BaseComponentStatus interface {
 	GetBinding() *corev1.LocalObjectReference
 	SetBinding(*corev1.LocalObjectReference)
 
+	GetResourceRecommendations() (corev1.ResourceList, corev1.ResourceList, corev1.ResourceList)
+	SetResourceRecommendations(corev1.ResourceList, corev1.ResourceList, corev1.ResourceList)
+
 	GetReferences() StatusReferences
 	SetReferences(StatusReferences)
 	SetReference(string, string)
+
+	GetCertificates() []StatusCertificate
+	NewStatusCertificate(name string, secretName string, issuer string, notAfter *metav1.Time, ready bool, message string) StatusCertificate
+	SetCertificates([]StatusCertificate)
+
+	GetReplicas() int32
+	GetReadyReplicas() int32
+	GetSelector() string
+	SetReplicas(replicas int32, readyReplicas int32, selector string)
+
+	GetBindings() []StatusBinding
+	NewStatusBinding(name string, secretName string) StatusBinding
+	SetBindings([]StatusBinding)
 }
 
 const (
 	// Status Condition Types
-	StatusConditionTypeReconciled     StatusConditionType = "Reconciled"
-	StatusConditionTypeResourcesReady StatusConditionType = "ResourcesReady"
-	StatusConditionTypeReady          StatusConditionType = "Ready"
+	StatusConditionTypeReconciled        StatusConditionType = "Reconciled"
+	StatusConditionTypeResourcesReady    StatusConditionType = "ResourcesReady"
+	StatusConditionTypeReady             StatusConditionType = "Ready"
+	StatusConditionTypeCertificatesReady StatusConditionType = "CertificatesReady"
+	StatusConditionTypeReplicasIgnored   StatusConditionType = "ReplicasIgnored"
 
 	// Status Endpoint Scopes
 	StatusEndpointScopeExternal StatusEndpointScope = "External"
@@ -99,6 +140,31 @@ type BaseComponentAutoscaling interface {
 	GetMinReplicas() *int32
 	GetMaxReplicas() int32
 	GetTargetCPUUtilizationPercentage() *int32
+	GetMetrics() []autoscalingv2.MetricSpec
+	GetBehavior() *autoscalingv2.HorizontalPodAutoscalerBehavior
+	GetKeda() BaseComponentKeda
+}
+
+// BaseComponentKeda represents basic KEDA ScaledObject configuration
+type BaseComponentKeda interface {
+	GetTriggers() []BaseComponentKedaTrigger
+	GetPollingInterval() *int32
+	GetCooldownPeriod() *int32
+}
+
+// BaseComponentKedaTrigger represents a KEDA scaler
+type BaseComponentKedaTrigger interface {
+	GetType() string
+	GetName() string
+	GetMetadata() map[string]string
+	GetMetricType() autoscalingv2.MetricTargetType
+	GetAuthenticationRef() BaseComponentKedaAuthenticationRef
+}
+
+// BaseComponentKedaAuthenticationRef represents a reference to the credentials of a KEDA scaler
+type BaseComponentKedaAuthenticationRef interface {
+	GetName() string
+	GetKind() string
 }
 
 // BaseComponentStorage represents basic PVC configuration
@@ -119,7 +185,24 @@ type BaseComponentService interface {
 	GetPorts() []corev1.ServicePort
 	GetAnnotations() map[string]string
 	GetCertificateSecretRef() *string
+	GetCertificate() BaseComponentServiceCertificate
 	GetBindable() *bool
+	GetMTLS() *bool
+}
+
+// BaseComponentServiceCertificate represents the parameters of the service certificate requested from cert-manager
+type BaseComponentServiceCertificate interface {
+	GetIssuerRef() BaseComponentIssuerRef
+	GetDuration() *metav1.Duration
+	GetRenewBefore() *metav1.Duration
+	GetPrivateKey() BaseComponentCertificatePrivateKey
+	GetDNSNames() []string
+}
+
+// BaseComponentCertificatePrivateKey represents the private key parameters of a certificate
+type BaseComponentCertificatePrivateKey interface {
+	GetAlgorithm() string
+	GetSize() int
 }
 
 // BaseComponentNetworkPolicy represents a basic network policy configuration
@@ -143,6 +226,111 @@ type BaseComponentRoute interface {
 	GetPath() string
 	GetPathType() networkingv1.PathType
 	GetCertificateSecretRef() *string
+	GetCertificate() BaseComponentRouteCertificate
+	GetAdditionalPaths() []BaseComponentRoutePath
+	GetIngressClassName() *string
+	GetGateway() BaseComponentGateway
+}
+
+// BaseComponentRouteCertificate represents a certificate requested from cert-manager for the route
+type BaseComponentRouteCertificate interface {
+	GetIssuerRef() BaseComponentIssuerRef
+	GetDuration() *metav1.Duration
+	GetDNSNames() []string
+}
+
+// BaseComponentIssuerRef represents a reference to a cert-manager Issuer or ClusterIssuer
+type BaseComponentIssuerRef interface {
+	GetName() string
+	GetKind() string
+}
+
+// BaseComponentRoutePath represents an additional host and path that expose the application
+type BaseComponentRoutePath interface {
+	GetHost() string
+	GetPath() string
+	GetPathType() networkingv1.PathType
+	GetPort() *intstr.IntOrString
+}
+
+// BaseComponentBinding represents a service binding
+type BaseComponentBinding interface {
+	GetName() string
+	GetService() BaseComponentBindingService
+	GetEnv() []BaseComponentBindingEnv
+}
+
+// BaseComponentBindingService represents a reference to a service to bind to
+type BaseComponentBindingService interface {
+	GetAPIVersion() string
+	GetKind() string
+	GetName() string
+}
+
+// BaseComponentBindingEnv represents an environment variable set from an entry of a binding secret
+type BaseComponentBindingEnv interface {
+	GetName() string
+	GetKey() string
+}
+
+// BaseComponentGateway represents the parent Gateway of Gateway API routes
+type BaseComponentGateway interface {
+	GetName() string
+	GetNamespace() string
+	GetSectionName() string
+}
+
+// BaseComponentDisruptionBudget represents basic PodDisruptionBudget configuration
+type BaseComponentDisruptionBudget interface {
+	GetMinAvailable() *intstr.IntOrString
+	GetMaxUnavailable() *intstr.IntOrString
+}
+
+// BaseComponentVerticalScaling represents basic VerticalPodAutoscaler configuration
+type BaseComponentVerticalScaling interface {
+	GetUpdateMode() string
+	GetControlledResources() []corev1.ResourceName
+	GetMinAllowed() corev1.ResourceList
+	GetMaxAllowed() corev1.ResourceList
+}
+
+// BaseComponentRollout represents basic canary and blue/green rollout configuration
+type BaseComponentRollout interface {
+	GetStrategy() string
+	GetSteps() []BaseComponentRolloutStep
+	GetCanaryReplicas() int32
+	GetAutoPromotionSeconds() int32
+	GetProgressDeadlineSeconds() int32
+	GetAbort() bool
+}
+
+// BaseComponentRolloutStep represents a traffic step of a canary rollout
+type BaseComponentRolloutStep interface {
+	GetWeight() int32
+	GetPauseSeconds() *int32
+}
+
+// BaseComponentKnative represents basic Knative Service configuration
+type BaseComponentKnative interface {
+	GetTraffic() []BaseComponentKnativeTraffic
+	GetContainerConcurrency() *int64
+	GetTimeoutSeconds() *int64
+	GetAutoscaling() BaseComponentKnativeAutoscaling
+}
+
+// BaseComponentKnativeAutoscaling represents basic Knative autoscaler configuration
+type BaseComponentKnativeAutoscaling interface {
+	GetClass() *string
+	GetMetric() *string
+	GetTarget() *int32
+}
+
+// BaseComponentKnativeTraffic represents a traffic target of a Knative Service
+type BaseComponentKnativeTraffic interface {
+	GetRevisionName() string
+	GetLatestRevision() *bool
+	GetPercent() *int64
+	GetTag() string
 }
 
 // BaseComponentAffinity describes deployment and pod affinity
@@ -211,4 +399,9 @@ type BaseComponent interface {
 	GetAffinity() BaseComponentAffinity
 	GetSecurityContext() *corev1.SecurityContext
 	GetManageTLS() *bool
+	GetDisruptionBudget() BaseComponentDisruptionBudget
+	GetVerticalScaling() BaseComponentVerticalScaling
+	GetRollout() BaseComponentRollout
+	GetKnative() BaseComponentKnative
+	GetBindings() []BaseComponentBinding
 }
@@ -19,7 +19,8 @@ import (
 var _ handler.EventHandler = &EnqueueRequestsForCustomIndexField{}
 
 const (
-	indexFieldImageStreamName = "spec.applicationImage"
+	indexFieldImageStreamName   = "spec.applicationImage"
+	indexFieldBindingSecretName = "status.bindings.secretName"
 )
 
 // EnqueueRequestsForCustomIndexField enqueues reconcile Requests Runtime Components if the app is relying on
@@ -101,3 +102,54 @@ func (i *ImageStreamMatcher) Match(imageStreamTag metav1.Object) ([]appstacksv1b
 
 	return apps, nil
 }
+
+// BindingSecretMatcher implements CustomMatcher for the binding secrets of service bindings
+type BindingSecretMatcher struct {
+	Klient          client.Client
+	WatchNamespaces []string
+}
+
+// Match returns all applications that project the input secret as a binding secret
+func (b *BindingSecretMatcher) Match(secret metav1.Object) ([]appstacksv1beta2.RuntimeComponent, error) {
+	if !appstacksutils.IsClusterWide(b.WatchNamespaces) && !appstacksutils.ContainsString(b.WatchNamespaces, secret.GetNamespace()) {
+		return nil, nil
+	}
+	appList := &appstacksv1beta2.RuntimeComponentList{}
+	err := b.Klient.List(context.Background(),
+		appList,
+		client.InNamespace(secret.GetNamespace()),
+		client.MatchingFields{indexFieldBindingSecretName: secret.GetName()})
+	if err != nil {
+		return nil, err
+	}
+	return appList.Items, nil
+}
+
+// TrustBundleCAMatcher implements CustomMatcher for the secret with the CA certificate of the trust bundle of a namespace
+type TrustBundleCAMatcher struct {
+	Klient          client.Client
+	WatchNamespaces []string
+	SecretName      string
+}
+
+// Match returns all applications with mutual TLS enabled in the namespace of the input secret, which copy the CA
+// certificate from the secret into the trust bundle
+func (m *TrustBundleCAMatcher) Match(secret metav1.Object) ([]appstacksv1beta2.RuntimeComponent, error) {
+	if secret.GetName() != m.SecretName {
+		return nil, nil
+	}
+	if !appstacksutils.IsClusterWide(m.WatchNamespaces) && !appstacksutils.ContainsString(m.WatchNamespaces, secret.GetNamespace()) {
+		return nil, nil
+	}
+	appList := &appstacksv1beta2.RuntimeComponentList{}
+	if err := m.Klient.List(context.Background(), appList, client.InNamespace(secret.GetNamespace())); err != nil {
+		return nil, err
+	}
+	apps := []appstacksv1beta2.RuntimeComponent{}
+	for i := range appList.Items {
+		if appstacksutils.IsMTLSEnabled(&appList.Items[i]) {
+			apps = append(apps, appList.Items[i])
+		}
+	}
+	return apps, nil
+}
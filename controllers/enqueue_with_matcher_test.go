@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"testing"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTrustBundleCAMatcher(t *testing.T) {
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	appstacksv1beta2.AddToScheme(s)
+
+	mtls := true
+	component := func(name string, namespace string, mtls *bool) *appstacksv1beta2.RuntimeComponent {
+		return &appstacksv1beta2.RuntimeComponent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: "my-image",
+				Service: &appstacksv1beta2.RuntimeComponentService{Port: 8443, MTLS: mtls}},
+		}
+	}
+	cl := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(
+		component("frontend", "shop", &mtls),
+		component("backend", "shop", &mtls),
+		component("batch", "shop", nil),
+		component("other", "team", &mtls),
+	).Build()
+
+	tests := []struct {
+		name            string
+		watchNamespaces []string
+		secret          string
+		namespace       string
+		want            []string
+	}{
+		{"CA secret", []string{"shop", "team"}, "rco-ca-tls", "shop", []string{"backend", "frontend"}},
+		{"CA secret when watching all namespaces", []string{""}, "rco-ca-tls", "team", []string{"other"}},
+		{"other secret", []string{"shop"}, "frontend-svc-tls-cm", "shop", []string{}},
+		{"namespace not watched", []string{"team"}, "rco-ca-tls", "shop", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &TrustBundleCAMatcher{Klient: cl, WatchNamespaces: tt.watchNamespaces, SecretName: "rco-ca-tls"}
+			apps, err := m.Match(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tt.secret, Namespace: tt.namespace}})
+			if err != nil {
+				t.Fatalf("Match() error = %v", err)
+			}
+			got := []string{}
+			for _, app := range apps {
+				got = append(got, app.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Match() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Match() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
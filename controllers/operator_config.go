@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	"github.com/application-stacks/runtime-component-operator/common"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// OperatorConfigMapName is the name of the ConfigMap with the configuration of the operator
+const OperatorConfigMapName = "runtime-component-operator"
+
+var _ handler.EventHandler = &operatorConfigHandler{}
+
+// operatorConfigHandler loads the operator configuration when the operator ConfigMap changes, and enqueues reconcile
+// Requests for the RuntimeComponents affected by the change
+type operatorConfigHandler struct {
+	reconciler *RuntimeComponentReconciler
+}
+
+// Create implements EventHandler
+func (h *operatorConfigHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.handle(evt.Object.(*corev1.ConfigMap), q)
+}
+
+// Update implements EventHandler
+func (h *operatorConfigHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.handle(evt.ObjectNew.(*corev1.ConfigMap), q)
+}
+
+// Delete implements EventHandler
+func (h *operatorConfigHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.handle(nil, q)
+}
+
+// Generic implements EventHandler
+func (h *operatorConfigHandler) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+}
+
+func (h *operatorConfigHandler) handle(configMap *corev1.ConfigMap, q workqueue.RateLimitingInterface) {
+	changed := h.reconciler.loadOperatorConfig(configMap)
+	if len(changed) == 0 {
+		return
+	}
+	components := &appstacksv1beta2.RuntimeComponentList{}
+	if err := h.reconciler.GetClient().List(context.TODO(), components); err != nil {
+		h.reconciler.Log.Error(err, "Failed to list RuntimeComponents affected by the operator configuration change")
+		return
+	}
+	for i := range components.Items {
+		if appstacksutils.IsAffectedByConfig(&components.Items[i], changed) {
+			q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&components.Items[i])})
+		}
+	}
+}
+
+// loadOperatorConfig replaces the operator configuration with the configuration in the ConfigMap, or with the defaults
+// when the ConfigMap is nil, and returns the keys that changed. Invalid configuration is rejected with a warning event
+// on the ConfigMap, and the current configuration stays in effect.
+func (r *RuntimeComponentReconciler) loadOperatorConfig(configMap *corev1.ConfigMap) []string {
+	data := map[string]string{}
+	if configMap != nil {
+		data = configMap.Data
+	}
+	cfg, err := common.ParseOpConfig(data)
+	if err != nil {
+		r.Log.Error(err, "Rejected the operator configuration")
+		if configMap != nil {
+			r.GetRecorder().Event(configMap, corev1.EventTypeWarning, "InvalidConfiguration", err.Error())
+		}
+		return nil
+	}
+
+	changed := common.GetConfig().ChangedKeys(cfg)
+	common.SetConfig(cfg)
+	if len(changed) > 0 {
+		r.Log.Info("Loaded the operator configuration", "changed", changed)
+	}
+	return changed
+}
+
+// getOperatorConfigMapKey returns the name and namespace of the operator ConfigMap. When the operator runs locally,
+// the ConfigMap is in the first watched namespace.
+func getOperatorConfigMapKey(watchNamespaces []string) types.NamespacedName {
+	ns, _ := appstacksutils.GetOperatorNamespace()
+	if ns == "" && len(watchNamespaces) > 0 {
+		ns = watchNamespaces[0]
+	}
+	return types.NamespacedName{Name: OperatorConfigMapName, Namespace: ns}
+}
+
+// newOperatorConfigCache returns a cache that only holds the operator ConfigMap. The manager cache is limited to the
+// watched namespaces, which may not include the operator namespace, and holds every ConfigMap of the cluster when all
+// namespaces are watched.
+func newOperatorConfigCache(mgr ctrl.Manager, key types.NamespacedName) (cache.Cache, error) {
+	return cache.New(mgr.GetConfig(), operatorConfigCacheOptions(mgr.GetScheme(), mgr.GetRESTMapper(), key))
+}
+
+func operatorConfigCacheOptions(scheme *runtime.Scheme, mapper meta.RESTMapper, key types.NamespacedName) cache.Options {
+	return cache.Options{
+		Scheme:    scheme,
+		Mapper:    mapper,
+		Namespace: key.Namespace,
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", key.Name)},
+		},
+	}
+}
+
+// initOperatorConfig loads the operator configuration before the controller starts, so that the first reconciles
+// use it. The cache is not started yet, so the ConfigMap is read from the API server.
+func (r *RuntimeComponentReconciler) initOperatorConfig(key types.NamespacedName) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.GetAPIReader().Get(context.TODO(), key, configMap); err != nil {
+		if !kerrors.IsNotFound(err) {
+			r.Log.Error(err, "Failed to get the operator ConfigMap, using the default configuration")
+		}
+		configMap = nil
+	}
+	r.loadOperatorConfig(configMap)
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"testing"
+
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestOperatorConfigCache(t *testing.T) {
+	t.Setenv("WATCH_NAMESPACE", "apps")
+	t.Setenv("OPERATOR_NAMESPACE", "operators")
+
+	watchNamespaces, err := appstacksutils.GetWatchNamespaces()
+	if err != nil {
+		t.Fatalf("GetWatchNamespaces() error = %v", err)
+	}
+	key := getOperatorConfigMapKey(watchNamespaces)
+	if key.Namespace != "operators" || key.Name != OperatorConfigMapName {
+		t.Fatalf("operator ConfigMap = %v, want operators/%s", key, OperatorConfigMapName)
+	}
+
+	opts := operatorConfigCacheOptions(scheme.Scheme, nil, key)
+	if opts.Namespace != "operators" {
+		t.Errorf("cache namespace = %q, want the operator namespace", opts.Namespace)
+	}
+	var selector fields.Selector
+	for obj, s := range opts.SelectorsByObject {
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			selector = s.Field
+		}
+	}
+	if selector == nil {
+		t.Fatal("the ConfigMaps of the cache are not selected by name")
+	}
+
+	tests := []struct {
+		name      string
+		configMap string
+		want      bool
+	}{
+		{"operator ConfigMap", OperatorConfigMapName, true},
+		{"other ConfigMap", "my-app", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selector.Matches(fields.Set{"metadata.name": tt.configMap}); got != tt.want {
+				t.Errorf("selector %q matches %q = %v, want %v", selector, tt.configMap, got, tt.want)
+			}
+		})
+	}
+}
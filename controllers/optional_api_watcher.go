@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// CustomResourceDefinitionGVK is the kind of the CustomResourceDefinitions that install most of the optional APIs
+var CustomResourceDefinitionGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// optionalWatch is a watch of the resources of an optional API, which can only start once the API is installed on the cluster
+type optionalWatch struct {
+	gvk        schema.GroupVersionKind
+	source     source.Source
+	handler    handler.EventHandler
+	predicates []predicate.Predicate
+}
+
+// ownedWatch returns a watch of the resources of an optional API that are owned by RuntimeComponents
+func ownedWatch(gvk schema.GroupVersionKind, obj client.Object, predicates ...predicate.Predicate) optionalWatch {
+	return optionalWatch{
+		gvk:        gvk,
+		source:     &source.Kind{Type: obj},
+		handler:    &handler.EnqueueRequestForOwner{OwnerType: &appstacksv1beta2.RuntimeComponent{}, IsController: true},
+		predicates: predicates,
+	}
+}
+
+// optionalAPIWatcher starts the watches of the optional APIs that are installed after the operator started, and
+// reconciles the RuntimeComponents again so that they create the resources of the new APIs. The APIs are checked
+// when a CustomResourceDefinition changes, and when their discovery expires for the APIs that are not installed by
+// CustomResourceDefinitions or when the operator is not allowed to watch CustomResourceDefinitions.
+type optionalAPIWatcher struct {
+	reconciler *RuntimeComponentReconciler
+	log        logr.Logger
+	interval   time.Duration
+	pending    []optionalWatch
+	changed    chan struct{}
+	components chan event.GenericEvent
+}
+
+func newOptionalAPIWatcher(r *RuntimeComponentReconciler, interval time.Duration, pending []optionalWatch) *optionalAPIWatcher {
+	return &optionalAPIWatcher{
+		reconciler: r,
+		log:        r.Log.WithName("optional-api-watcher"),
+		interval:   interval,
+		pending:    pending,
+		changed:    make(chan struct{}, 1),
+		components: make(chan event.GenericEvent),
+	}
+}
+
+// Start implements manager.Runnable
+func (w *optionalAPIWatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for len(w.pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-w.changed:
+		}
+		w.startWatches(ctx)
+	}
+	return nil
+}
+
+// startWatches starts the pending watches of the APIs that are now installed on the cluster
+func (w *optionalAPIWatcher) startWatches(ctx context.Context) {
+	pending := []optionalWatch{}
+	started := false
+	for _, ow := range w.pending {
+		if ok, err := w.reconciler.IsGroupVersionSupported(ow.gvk.GroupVersion().String(), ow.gvk.Kind); err != nil || !ok {
+			pending = append(pending, ow)
+			continue
+		}
+		if err := w.reconciler.GetController().Watch(ow.source, ow.handler, ow.predicates...); err != nil {
+			w.log.Error(err, "Failed to watch newly installed API", "groupVersionKind", ow.gvk.String())
+			pending = append(pending, ow)
+			continue
+		}
+		w.log.Info("Started watching newly installed API", "groupVersionKind", ow.gvk.String())
+		started = true
+	}
+	w.pending = pending
+	if started {
+		w.reconcileComponents(ctx)
+	}
+}
+
+// reconcileComponents enqueues all the RuntimeComponents for reconcile
+func (w *optionalAPIWatcher) reconcileComponents(ctx context.Context) {
+	components := &appstacksv1beta2.RuntimeComponentList{}
+	if err := w.reconciler.GetClient().List(ctx, components); err != nil {
+		w.log.Error(err, "Failed to list RuntimeComponents to reconcile with the newly installed APIs")
+		return
+	}
+	for i := range components.Items {
+		select {
+		case w.components <- event.GenericEvent{Object: &components.Items[i]}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// crdChanged invalidates the discovery of the API group of a CustomResourceDefinition that changed, and checks the
+// pending watches again. CustomResourceDefinitions are named <plural>.<group>.
+func (w *optionalAPIWatcher) crdChanged(crd client.Object) {
+	if i := strings.Index(crd.GetName(), "."); i >= 0 {
+		if cache, err := w.reconciler.GetDiscoveryCache(); err == nil {
+			cache.Invalidate(crd.GetName()[i+1:])
+		}
+	}
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+// crdWatch returns a metadata-only watch of the CustomResourceDefinitions that calls crdChanged
+func (w *optionalAPIWatcher) crdWatch() (source.Source, handler.EventHandler) {
+	crd := &metav1.PartialObjectMetadata{}
+	crd.SetGroupVersionKind(CustomResourceDefinitionGVK)
+	return &source.Kind{Type: crd}, handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+			w.crdChanged(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+			w.crdChanged(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			w.crdChanged(e.Object)
+		},
+	}
+}
+
+// canWatchCRDs returns whether the operator is allowed to watch CustomResourceDefinitions. Watching them without
+// permission would block the start of the controller.
+func canWatchCRDs(r *appstacksutils.ReconcilerBase) bool {
+	gr := schema.GroupResource{Group: CustomResourceDefinitionGVK.Group, Resource: "customresourcedefinitions"}
+	for _, verb := range []string{"list", "watch"} {
+		if ok, err := r.IsAllowed(verb, gr); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
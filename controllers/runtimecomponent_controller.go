@@ -23,10 +23,10 @@ import (
 	"strings"
 
 	"github.com/application-stacks/runtime-component-operator/common"
-	"github.com/pkg/errors"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -40,16 +40,21 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/library-go/pkg/image/imageutil"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,8 +63,7 @@ import (
 // RuntimeComponentReconciler reconciles a RuntimeComponent object
 type RuntimeComponentReconciler struct {
 	appstacksutils.ReconcilerBase
-	Log             logr.Logger
-	watchNamespaces []string
+	Log logr.Logger
 }
 
 // +kubebuilder:rbac:groups=rc.app.stacks,resources=runtimecomponents;runtimecomponents/status;runtimecomponents/finalizers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
@@ -68,11 +72,17 @@ type RuntimeComponentReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=services;secrets;serviceaccounts;configmaps,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;tlsroutes,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;list;watch,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers,verbs=get;list;watch;create;update;delete,namespace=runtime-component-operator
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -81,57 +91,51 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
 	reqLogger.Info("Reconciling RuntimeComponent")
 
-	ns, err := appstacksutils.GetOperatorNamespace()
-	// When running the operator locally, `ns` will be empty string
-	if ns == "" {
-		// Since this method can be called directly from unit test, populate `watchNamespaces`.
-		if r.watchNamespaces == nil {
-			r.watchNamespaces, err = appstacksutils.GetWatchNamespaces()
-			if err != nil {
-				reqLogger.Error(err, "Error getting watch namespace")
-				return reconcile.Result{}, err
-			}
-		}
-		// If the operator is running locally, use the first namespace in the `watchNamespaces`
-		// `watchNamespaces` must have at least one item
-		ns = r.watchNamespaces[0]
-	}
-
-	configMap, err := r.GetOpConfigMap("runtime-component-operator", ns)
-	if err != nil {
-		reqLogger.Info("Failed to find runtime-component-operator config map")
-		common.Config = common.DefaultOpConfig()
-		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "runtime-component-operator", Namespace: ns}}
-		configMap.Data = common.Config
-	} else {
-		common.Config.LoadFromConfigMap(configMap)
-	}
-
-	_, err = controllerutil.CreateOrUpdate(context.TODO(), r.GetClient(), configMap, func() error {
-		configMap.Data = common.Config
-		return nil
-	})
-
-	if err != nil {
-		reqLogger.Info("Failed to update runtime-component-operator config map")
-	}
-
 	// Fetch the RuntimeComponent instance
 	instance := &appstacksv1beta2.RuntimeComponent{}
 	var ba common.BaseComponent = instance
-	err = r.GetClient().Get(context.TODO(), req.NamespacedName, instance)
+	err := r.GetClient().Get(context.TODO(), req.NamespacedName, instance)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			appstacksutils.DeleteComponentMetrics(req.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
-	// initialize the RuntimeComponent instance
+	// Clean up the resources that are not owned by the instance, such as the cert-manager resources shared by the
+	// components of the namespace, before the instance is removed
+	finalizerName := ba.GetGroupName() + "/finalizer"
+	if instance.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(instance, finalizerName) {
+			if err := r.cleanupCertificates(instance); err != nil {
+				reqLogger.Error(err, "Failed to clean up resources of RuntimeComponent")
+				r.GetRecorder().Event(instance, corev1.EventTypeWarning, "CleanupFailed", err.Error())
+				return reconcile.Result{}, err
+			}
+			appstacksutils.DeleteComponentMetrics(req.NamespacedName)
+			controllerutil.RemoveFinalizer(instance, finalizerName)
+			if err := r.GetClient().Update(context.TODO(), instance); err != nil {
+				reqLogger.Error(err, "Failed to remove finalizer from RuntimeComponent")
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// The finalizer is only needed while the instance uses cert-manager resources that are not deleted with it
+	if err := r.updateFinalizer(instance, finalizerName); err != nil {
+		reqLogger.Error(err, "Failed to update the finalizer of RuntimeComponent")
+		return reconcile.Result{}, err
+	}
+
+	// Apply defaults to the in-memory copy only. The reconciler never writes the spec back to the cluster,
+	// and the defaulting webhook only persists spec.replicas, so the defaulted values do not fight with
+	// tools that own the user's manifest.
 	instance.Initialize()
 	_, err = appstacksutils.Validate(instance)
 	// If there's any validation error, don't bother with requeuing
@@ -147,12 +151,6 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		instance.Annotations = appstacksutils.MergeMaps(instance.Annotations, appstacksutils.GetOpenShiftAnnotations(instance))
 	}
 
-	err = r.GetClient().Update(context.TODO(), instance)
-	if err != nil {
-		reqLogger.Error(err, "Error updating RuntimeComponent")
-		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
-	}
-
 	// currentGen := instance.Generation
 	// if currentGen == 1 {
 	// 	return reconcile.Result{RequeueAfter: common.ReconcileInterval * time.Second}, nil
@@ -162,6 +160,10 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		Name:      instance.Name,
 		Namespace: instance.Namespace,
 	}
+	canaryMeta := metav1.ObjectMeta{
+		Name:      appstacksutils.GetCanaryName(instance),
+		Namespace: instance.Namespace,
+	}
 
 	imageReferenceOld := instance.Status.ImageReference
 	instance.Status.ImageReference = instance.Spec.ApplicationImage
@@ -238,7 +240,10 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			&appsv1.Deployment{ObjectMeta: defaultMeta},
 			&appsv1.StatefulSet{ObjectMeta: defaultMeta},
 			&autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta},
+			&appsv1.Deployment{ObjectMeta: canaryMeta},
+			&corev1.Service{ObjectMeta: canaryMeta},
 		}
+		instance.Status.Rollout = nil
 		err = r.DeleteResources(resources)
 		if err != nil {
 			reqLogger.Error(err, "Failed to clean up non-Knative resources")
@@ -247,16 +252,48 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 		if ok, _ := r.IsGroupVersionSupported(networkingv1.SchemeGroupVersion.String(), "Ingress"); ok {
 			r.DeleteResource(&networkingv1.Ingress{ObjectMeta: defaultMeta})
+			r.DeleteResource(&networkingv1.Ingress{ObjectMeta: canaryMeta})
+		}
+
+		if ok, _ := r.IsGroupVersionSupported(policyv1.SchemeGroupVersion.String(), "PodDisruptionBudget"); ok {
+			r.DeleteResource(&policyv1.PodDisruptionBudget{ObjectMeta: defaultMeta})
+		}
+
+		if ok, _ := r.IsGroupVersionSupported(appstacksutils.ScaledObjectGVK.GroupVersion().String(), appstacksutils.ScaledObjectGVK.Kind); ok {
+			r.DeleteResource(newScaledObject(defaultMeta))
+		}
+
+		if ok, _ := r.IsGroupVersionSupported(appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String(), appstacksutils.VerticalPodAutoscalerGVK.Kind); ok {
+			r.DeleteResource(newVerticalPodAutoscaler(defaultMeta))
+		}
+
+		for _, gvk := range []schema.GroupVersionKind{appstacksutils.HTTPRouteGVK, appstacksutils.TLSRouteGVK} {
+			if ok, _ := r.IsGroupVersionSupported(gvk.GroupVersion().String(), gvk.Kind); ok {
+				r.DeleteResource(newGatewayRoute(gvk, defaultMeta))
+			}
 		}
 
 		if r.IsOpenShift() {
 			route := &routev1.Route{ObjectMeta: defaultMeta}
 			err = r.DeleteResource(route)
+			if err == nil {
+				err = r.deleteAdditionalRoutes(instance, nil)
+			}
 			if err != nil {
 				reqLogger.Error(err, "Failed to clean up non-Knative resource Route")
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
 		}
+		if err = r.ReconcileRouteCertificate(instance, false); err != nil {
+			reqLogger.Error(err, "Failed to clean up non-Knative resource Certificate of the route")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+		if err = r.ReconcileMTLS(ba, "rco", "runtime-component-operator", false); err != nil {
+			reqLogger.Error(err, "Failed to clean up non-Knative resource Certificate of the mutual TLS client")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+		instance.Status.TrustedPeers = nil
+		instance.Status.Bindings = nil
 
 		if isKnativeSupported {
 			ksvc := &servingv1.Service{ObjectMeta: defaultMeta}
@@ -271,7 +308,7 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			}
 			return r.ManageSuccess(common.StatusConditionTypeReconciled, instance)
 		}
-		return r.ManageError(errors.New("failed to reconcile Knative service as operator could not find Knative CRDs"), common.StatusConditionTypeReconciled, instance)
+		return r.ManageError(fmt.Errorf("failed to reconcile Knative service as %s is %w", servingv1.SchemeGroupVersion.String(), appstacksutils.ErrNotSupported), common.StatusConditionTypeReconciled, instance)
 	}
 
 	if isKnativeSupported {
@@ -292,6 +329,17 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		ba.GetStatus().SetReference(common.StatusReferenceCertSecretName, *ba.GetService().GetCertificateSecretRef())
 	}
 
+	err = r.ReconcileMTLS(ba, "rco", "runtime-component-operator", useCertmanager)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile mutual TLS")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+	instance.Status.TrustedPeers, err = r.getTrustedPeers(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to list the mutual TLS peers of the application")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
 	svc := &corev1.Service{ObjectMeta: defaultMeta}
 	err = r.CreateOrUpdate(svc, instance, func() error {
 		appstacksutils.CustomizeService(svc, ba)
@@ -355,6 +403,12 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
 
+		instance.Status.Rollout = nil
+		if err := r.reconcileCanary(instance); err != nil {
+			reqLogger.Error(err, "Failed to delete canary Deployment")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+
 		statefulSet := &appsv1.StatefulSet{ObjectMeta: defaultMeta}
 		err = r.CreateOrUpdate(statefulSet, instance, func() error {
 			appstacksutils.CustomizeStatefulSet(statefulSet, instance)
@@ -362,6 +416,9 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			if err := appstacksutils.CustomizePodWithSVCCertificate(&statefulSet.Spec.Template, instance, r.GetClient()); err != nil {
 				return err
 			}
+			if err := appstacksutils.CustomizePodWithBindings(&statefulSet.Spec.Template, instance, r.GetClient()); err != nil {
+				return err
+			}
 			appstacksutils.CustomizePersistence(statefulSet, instance)
 			return nil
 		})
@@ -387,6 +444,11 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			reqLogger.Error(err, "Failed to delete headless Service")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
+		if err := r.updateRolloutStatus(instance); err != nil {
+			reqLogger.Error(err, "Failed to update the rollout status")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+
 		deploy := &appsv1.Deployment{ObjectMeta: defaultMeta}
 		err = r.CreateOrUpdate(deploy, instance, func() error {
 			appstacksutils.CustomizeDeployment(deploy, instance)
@@ -394,6 +456,13 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			if err := appstacksutils.CustomizePodWithSVCCertificate(&deploy.Spec.Template, instance, r.GetClient()); err != nil {
 				return err
 			}
+			if err := appstacksutils.CustomizePodWithBindings(&deploy.Spec.Template, instance, r.GetClient()); err != nil {
+				return err
+			}
+			// The new image only reaches the stable Deployment once the rollout is promoted
+			if instance.Status.Rollout != nil {
+				appstacksutils.GetAppContainer(deploy.Spec.Template.Spec.Containers).Image = instance.Status.Rollout.StableImage
+			}
 			return nil
 		})
 		if err != nil {
@@ -401,14 +470,55 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
 
+		if err := r.reconcileCanary(instance); err != nil {
+			reqLogger.Error(err, "Failed to reconcile canary Deployment")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+
 	}
 
-	if instance.Spec.Autoscaling != nil {
+	r.ReportIgnoredReplicas(instance)
+
+	isKedaSupported, _ := r.IsGroupVersionSupported(appstacksutils.ScaledObjectGVK.GroupVersion().String(), appstacksutils.ScaledObjectGVK.Kind)
+	if instance.Spec.Autoscaling != nil && instance.Spec.Autoscaling.Keda != nil {
+		if !isKedaSupported {
+			return r.ManageError(fmt.Errorf("failed to reconcile ScaledObject as %s is %w", appstacksutils.ScaledObjectGVK.GroupVersion().String(), appstacksutils.ErrNotSupported), common.StatusConditionTypeReconciled, instance)
+		}
+
+		// KEDA manages its own HorizontalPodAutoscaler for the ScaledObject, so the two autoscalers don't compete
 		hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
-		err = r.CreateOrUpdate(hpa, instance, func() error {
-			appstacksutils.CustomizeHPA(hpa, instance)
-			return nil
+		err = r.DeleteResource(hpa)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete HorizontalPodAutoscaler")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+
+		so := newScaledObject(defaultMeta)
+		err = r.CreateOrUpdate(so, instance, func() error {
+			return appstacksutils.CustomizeScaledObject(so, instance)
 		})
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile ScaledObject")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else if instance.Spec.Autoscaling != nil {
+		// Use autoscaling/v2 when the cluster supports it, as autoscaling/v1 only scales on CPU utilization
+		isHPAV2Supported, _ := r.IsGroupVersionSupported(autoscalingv2.SchemeGroupVersion.String(), "HorizontalPodAutoscaler")
+		if isHPAV2Supported {
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
+			err = r.CreateOrUpdate(hpa, instance, func() error {
+				appstacksutils.CustomizeHPAV2(hpa, instance)
+				return nil
+			})
+		} else if len(instance.Spec.Autoscaling.Metrics) > 0 || instance.Spec.Autoscaling.Behavior != nil {
+			err = fmt.Errorf("spec.autoscaling.metrics and spec.autoscaling.behavior require %s, which is %w", autoscalingv2.SchemeGroupVersion.String(), appstacksutils.ErrNotSupported)
+		} else {
+			hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
+			err = r.CreateOrUpdate(hpa, instance, func() error {
+				appstacksutils.CustomizeHPA(hpa, instance)
+				return nil
+			})
+		}
 
 		if err != nil {
 			reqLogger.Error(err, "Failed to reconcile HorizontalPodAutoscaler")
@@ -423,11 +533,124 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	if isKedaSupported && (instance.Spec.Autoscaling == nil || instance.Spec.Autoscaling.Keda == nil) {
+		err = r.DeleteResource(newScaledObject(defaultMeta))
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete ScaledObject")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+
+	if ok, err := r.IsGroupVersionSupported(policyv1.SchemeGroupVersion.String(), "PodDisruptionBudget"); err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", policyv1.SchemeGroupVersion.String()))
+		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	} else if ok {
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: defaultMeta}
+		// Autoscaled workloads get a default budget, so that a node drain doesn't evict all replicas at once
+		if instance.Spec.DisruptionBudget != nil || instance.Spec.Autoscaling != nil {
+			err = r.CreateOrUpdate(pdb, instance, func() error {
+				appstacksutils.CustomizePodDisruptionBudget(pdb, instance)
+				return nil
+			})
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile PodDisruptionBudget")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		} else {
+			err = r.DeleteResource(pdb)
+			if err != nil {
+				reqLogger.Error(err, "Failed to delete PodDisruptionBudget")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		}
+	} else if instance.Spec.DisruptionBudget != nil {
+		return r.ManageError(fmt.Errorf("failed to reconcile PodDisruptionBudget as %s is %w", policyv1.SchemeGroupVersion.String(), appstacksutils.ErrNotSupported), common.StatusConditionTypeReconciled, instance)
+	}
+
+	if ok, err := r.IsGroupVersionSupported(appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String(), appstacksutils.VerticalPodAutoscalerGVK.Kind); err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String()))
+		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	} else if ok {
+		vpa := newVerticalPodAutoscaler(defaultMeta)
+		if instance.Spec.VerticalScaling != nil {
+			err = r.CreateOrUpdate(vpa, instance, func() error {
+				return appstacksutils.CustomizeVerticalPodAutoscaler(vpa, instance)
+			})
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile VerticalPodAutoscaler")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			// Report the recommendations, so that they can be copied into spec.resources when the update mode is Off
+			instance.Status.SetResourceRecommendations(appstacksutils.GetResourceRecommendations(vpa))
+		} else {
+			err = r.DeleteResource(vpa)
+			if err != nil {
+				reqLogger.Error(err, "Failed to delete VerticalPodAutoscaler")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			instance.Status.SetResourceRecommendations(nil, nil, nil)
+		}
+	} else if instance.Spec.VerticalScaling != nil {
+		return r.ManageError(fmt.Errorf("failed to reconcile VerticalPodAutoscaler as %s is %w", appstacksutils.VerticalPodAutoscalerGVK.GroupVersion().String(), appstacksutils.ErrNotSupported), common.StatusConditionTypeReconciled, instance)
+	}
+
+	useGatewayAPI, err := r.UseGatewayAPI(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to check if the application is exposed through a Gateway")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+	// The application is exposed through a Gateway API route instead of a Route or an Ingress
+	exposeGatewayRoute := useGatewayAPI && instance.Spec.Expose != nil && *instance.Spec.Expose
+	exposeRoute := !exposeGatewayRoute && instance.Spec.Expose != nil && *instance.Spec.Expose
+
+	for _, gvk := range []schema.GroupVersionKind{appstacksutils.HTTPRouteGVK, appstacksutils.TLSRouteGVK} {
+		if ok, _ := r.IsGroupVersionSupported(gvk.GroupVersion().String(), gvk.Kind); !ok {
+			continue
+		}
+		gatewayRoute := newGatewayRoute(gvk, defaultMeta)
+		if exposeGatewayRoute && (gvk == appstacksutils.TLSRouteGVK) == appstacksutils.UseTLSRoute(instance) {
+			err = r.CreateOrUpdate(gatewayRoute, instance, func() error {
+				if gvk == appstacksutils.TLSRouteGVK {
+					appstacksutils.CustomizeTLSRoute(gatewayRoute, instance)
+				} else {
+					appstacksutils.CustomizeHTTPRoute(gatewayRoute, instance)
+					if appstacksutils.IsCanaryActive(instance) {
+						appstacksutils.SplitHTTPRouteTraffic(gatewayRoute, instance, instance.Status.Rollout.CanaryWeight)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile "+gvk.Kind)
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		} else {
+			err = r.DeleteResource(gatewayRoute)
+			if err != nil {
+				reqLogger.Error(err, "Failed to delete "+gvk.Kind)
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		}
+	}
+
+	if err = r.ReconcileGatewayProtocol(instance, exposeGatewayRoute); err != nil {
+		reqLogger.Error(err, "Failed to get the protocol of the Gateway")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
+	// The Route or Ingress only uses the requested certificate once it is ready, and the Certificate is watched
+	// to reconcile again when it becomes ready
+	if err = r.ReconcileRouteCertificate(instance, exposeRoute); err != nil {
+		reqLogger.Error(err, "Failed to reconcile the Certificate of the route")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
 	if ok, err := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route"); err != nil {
 		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", routev1.SchemeGroupVersion.String()))
 		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 	} else if ok {
-		if instance.Spec.Expose != nil && *instance.Spec.Expose {
+		primaryHost := ""
+		if exposeRoute {
 			route := &routev1.Route{ObjectMeta: defaultMeta}
 			err = r.CreateOrUpdate(route, instance, func() error {
 				key, cert, caCert, destCACert, err := r.GetRouteTLSValues(ba)
@@ -435,6 +658,9 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 					return err
 				}
 				appstacksutils.CustomizeRoute(route, ba, key, cert, caCert, destCACert)
+				if appstacksutils.IsCanaryActive(instance) {
+					appstacksutils.SplitRouteTraffic(route, ba, instance.Status.Rollout.CanaryWeight)
+				}
 
 				return nil
 			})
@@ -442,6 +668,7 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 				reqLogger.Error(err, "Failed to reconcile Route")
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
+			primaryHost = appstacksutils.GetAdmittedRouteHost(route)
 		} else {
 			route := &routev1.Route{ObjectMeta: defaultMeta}
 			err = r.DeleteResource(route)
@@ -450,13 +677,50 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
 		}
+
+		// A Route has a single host and path, so each additional path of spec.route gets its own Route
+		additionalRoutes := map[string]bool{}
+		if exposeRoute {
+			for i, path := range appstacksutils.GetRoutePaths(ba)[1:] {
+				route := &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: appstacksutils.GetAdditionalRouteName(ba, i), Namespace: instance.Namespace}}
+				additionalRoutes[route.Name] = true
+				// Paths without a host use the host that OpenShift generates for the primary Route. The Route is
+				// watched, so the path is exposed when the primary Route is admitted.
+				if path.Host == "" {
+					if primaryHost == "" {
+						reqLogger.Info("Waiting for the Route to be admitted to expose the additional path " + path.Path)
+						continue
+					}
+					path.Host = primaryHost
+				}
+				err = r.CreateOrUpdate(route, instance, func() error {
+					key, cert, caCert, destCACert, err := r.GetRouteTLSValues(ba)
+					if err != nil {
+						return err
+					}
+					appstacksutils.CustomizeAdditionalRoute(route, ba, path, key, cert, caCert, destCACert)
+					if appstacksutils.IsCanaryActive(instance) {
+						appstacksutils.SplitRouteTraffic(route, ba, instance.Status.Rollout.CanaryWeight)
+					}
+					return nil
+				})
+				if err != nil {
+					reqLogger.Error(err, "Failed to reconcile Route "+route.Name)
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			}
+		}
+		if err = r.deleteAdditionalRoutes(instance, additionalRoutes); err != nil {
+			reqLogger.Error(err, "Failed to delete Routes of removed paths")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
 	} else {
 
 		if ok, err := r.IsGroupVersionSupported(networkingv1.SchemeGroupVersion.String(), "Ingress"); err != nil {
 			reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", networkingv1.SchemeGroupVersion.String()))
 			r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		} else if ok {
-			if instance.Spec.Expose != nil && *instance.Spec.Expose {
+			if exposeRoute {
 				ing := &networkingv1.Ingress{ObjectMeta: defaultMeta}
 				err = r.CreateOrUpdate(ing, instance, func() error {
 					appstacksutils.CustomizeIngress(ing, instance)
@@ -474,6 +738,24 @@ func (r *RuntimeComponentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 				}
 			}
+
+			canaryIng := &networkingv1.Ingress{ObjectMeta: canaryMeta}
+			if exposeRoute && appstacksutils.IsCanaryActive(instance) {
+				err = r.CreateOrUpdate(canaryIng, instance, func() error {
+					appstacksutils.CustomizeCanaryIngress(canaryIng, instance, instance.Status.Rollout.CanaryWeight)
+					return nil
+				})
+				if err != nil {
+					reqLogger.Error(err, "Failed to reconcile canary Ingress")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			} else {
+				err = r.DeleteResource(canaryIng)
+				if err != nil {
+					reqLogger.Error(err, "Failed to delete canary Ingress")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			}
 		}
 	}
 
@@ -525,6 +807,15 @@ func (r *RuntimeComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return nil
 	})
 
+	mgr.GetFieldIndexer().IndexField(context.Background(), &appstacksv1beta2.RuntimeComponent{}, indexFieldBindingSecretName, func(obj client.Object) []string {
+		instance := obj.(*appstacksv1beta2.RuntimeComponent)
+		secretNames := []string{}
+		for _, b := range instance.Status.Bindings {
+			secretNames = append(secretNames, b.SecretName)
+		}
+		return secretNames
+	})
+
 	watchNamespaces, err := appstacksutils.GetWatchNamespaces()
 	if err != nil {
 		r.Log.Error(err, "Failed to get watch namespace")
@@ -540,7 +831,8 @@ func (r *RuntimeComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	pred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			// Ignore updates to CR status in which case metadata.Generation does not change
-			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() && (isClusterWide || watchNamespacesMap[e.ObjectNew.GetNamespace()])
+			changed := e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() || (e.ObjectOld.GetDeletionTimestamp() == nil) != (e.ObjectNew.GetDeletionTimestamp() == nil)
+			return changed && (isClusterWide || watchNamespacesMap[e.ObjectNew.GetNamespace()])
 		},
 		CreateFunc: func(e event.CreateEvent) bool {
 			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
@@ -588,37 +880,192 @@ func (r *RuntimeComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Service{}, builder.WithPredicates(predSubResource)).
 		Owns(&corev1.Secret{}, builder.WithPredicates(predSubResource)).
 		Owns(&appsv1.Deployment{}, builder.WithPredicates(predSubResWithGenCheck)).
-		Owns(&appsv1.StatefulSet{}, builder.WithPredicates(predSubResWithGenCheck)).
-		Owns(&autoscalingv1.HorizontalPodAutoscaler{}, builder.WithPredicates(predSubResource))
+		Owns(&appsv1.StatefulSet{}, builder.WithPredicates(predSubResWithGenCheck))
 
-	ok, _ := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+	ok, _ := r.IsGroupVersionSupported(autoscalingv2.SchemeGroupVersion.String(), "HorizontalPodAutoscaler")
 	if ok {
-		b = b.Owns(&routev1.Route{}, builder.WithPredicates(predSubResource))
+		b = b.Owns(&autoscalingv2.HorizontalPodAutoscaler{}, builder.WithPredicates(predSubResource))
+	} else {
+		b = b.Owns(&autoscalingv1.HorizontalPodAutoscaler{}, builder.WithPredicates(predSubResource))
 	}
-	ok, _ = r.IsGroupVersionSupported(networkingv1.SchemeGroupVersion.String(), "Ingress")
-	if ok {
-		b = b.Owns(&networkingv1.Ingress{}, builder.WithPredicates(predSubResource))
+
+	// Watches of optional APIs, which start when the API is installed after the operator started
+	watches := []optionalWatch{
+		ownedWatch(routev1.SchemeGroupVersion.WithKind("Route"), &routev1.Route{}, predSubResource),
+		ownedWatch(networkingv1.SchemeGroupVersion.WithKind("Ingress"), &networkingv1.Ingress{}, predSubResource),
+		ownedWatch(servingv1.SchemeGroupVersion.WithKind("Service"), &servingv1.Service{}, predSubResource),
+		ownedWatch(policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"), &policyv1.PodDisruptionBudget{}, predSubResource),
+		ownedWatch(certmanagerv1.SchemeGroupVersion.WithKind("Certificate"), &certmanagerv1.Certificate{}, predSubResource),
+		ownedWatch(prometheusv1.SchemeGroupVersion.WithKind("ServiceMonitor"), &prometheusv1.ServiceMonitor{}, predSubResource),
+		{
+			gvk:    imagev1.SchemeGroupVersion.WithKind("ImageStream"),
+			source: &source.Kind{Type: &imagev1.ImageStream{}},
+			handler: &EnqueueRequestsForCustomIndexField{
+				Matcher: &ImageStreamMatcher{
+					Klient:          mgr.GetClient(),
+					WatchNamespaces: watchNamespaces,
+				},
+			},
+		},
 	}
-	ok, _ = r.IsGroupVersionSupported(servingv1.SchemeGroupVersion.String(), "Service")
-	if ok {
-		b = b.Owns(&servingv1.Service{}, builder.WithPredicates(predSubResource))
+	for _, gvk := range []schema.GroupVersionKind{appstacksutils.ScaledObjectGVK, appstacksutils.VerticalPodAutoscalerGVK, appstacksutils.HTTPRouteGVK, appstacksutils.TLSRouteGVK} {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		watches = append(watches, ownedWatch(gvk, obj, predSubResource))
 	}
-	ok, _ = r.IsGroupVersionSupported(prometheusv1.SchemeGroupVersion.String(), "ServiceMonitor")
-	if ok {
-		b = b.Owns(&prometheusv1.ServiceMonitor{}, builder.WithPredicates(predSubResource))
+	pending := []optionalWatch{}
+	for _, ow := range watches {
+		if ok, _ = r.IsGroupVersionSupported(ow.gvk.GroupVersion().String(), ow.gvk.Kind); ok {
+			b = b.Watches(ow.source, ow.handler, builder.WithPredicates(ow.predicates...))
+		} else {
+			pending = append(pending, ow)
+		}
 	}
-	ok, _ = r.IsGroupVersionSupported(imagev1.SchemeGroupVersion.String(), "ImageStream")
-	if ok {
-		b = b.Watches(&source.Kind{Type: &imagev1.ImageStream{}}, &EnqueueRequestsForCustomIndexField{
-			Matcher: &ImageStreamMatcher{
-				Klient:          mgr.GetClient(),
-				WatchNamespaces: watchNamespaces,
-			},
-		})
+	discoveryCache, err := r.GetDiscoveryCache()
+	if err != nil {
+		return err
 	}
-	return b.Complete(r)
+	apiWatcher := newOptionalAPIWatcher(r, discoveryCache.GetTTL(), pending)
+	b = b.Watches(&source.Channel{Source: apiWatcher.components}, &handler.EnqueueRequestForObject{})
+	b = b.Watches(&source.Kind{Type: &corev1.Secret{}}, &EnqueueRequestsForCustomIndexField{
+		Matcher: &BindingSecretMatcher{
+			Klient:          mgr.GetClient(),
+			WatchNamespaces: watchNamespaces,
+		},
+	})
+	// The trust bundle is updated when the CA certificate of the namespace is renewed
+	b = b.Watches(&source.Kind{Type: &corev1.Secret{}}, &EnqueueRequestsForCustomIndexField{
+		Matcher: &TrustBundleCAMatcher{
+			Klient:          mgr.GetClient(),
+			WatchNamespaces: watchNamespaces,
+			SecretName:      appstacksutils.GetCASecretName("rco"),
+		},
+	})
+
+	configMapKey := getOperatorConfigMapKey(watchNamespaces)
+	r.initOperatorConfig(configMapKey)
+	configCache, err := newOperatorConfigCache(mgr, configMapKey)
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(configCache); err != nil {
+		return err
+	}
+	b = b.Watches(source.NewKindWithCache(&corev1.ConfigMap{}, configCache), &operatorConfigHandler{reconciler: r})
+
+	c, err := b.Build(r)
+	if err != nil {
+		return err
+	}
+	r.SetController(c)
+	if canWatchCRDs(&r.ReconcilerBase) {
+		if err := c.Watch(apiWatcher.crdWatch()); err != nil {
+			return err
+		}
+	} else {
+		r.Log.Info("Not allowed to watch CustomResourceDefinitions, newly installed APIs are discovered every " + discoveryCache.GetTTL().String())
+	}
+	return mgr.Add(apiWatcher)
+}
+
+// newScaledObject returns an empty KEDA ScaledObject with the given name and namespace
+func newScaledObject(objectMeta metav1.ObjectMeta) *unstructured.Unstructured {
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(appstacksutils.ScaledObjectGVK)
+	so.SetName(objectMeta.Name)
+	so.SetNamespace(objectMeta.Namespace)
+	return so
+}
+
+// newVerticalPodAutoscaler returns an empty VerticalPodAutoscaler with the given name and namespace
+func newVerticalPodAutoscaler(objectMeta metav1.ObjectMeta) *unstructured.Unstructured {
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(appstacksutils.VerticalPodAutoscalerGVK)
+	vpa.SetName(objectMeta.Name)
+	vpa.SetNamespace(objectMeta.Namespace)
+	return vpa
+}
+
+// deleteAdditionalRoutes deletes the Routes created for additional paths of spec.route, except the ones to keep
+func (r *RuntimeComponentReconciler) deleteAdditionalRoutes(instance *appstacksv1beta2.RuntimeComponent, keep map[string]bool) error {
+	routes := &routev1.RouteList{}
+	err := r.GetClient().List(context.TODO(), routes, client.InNamespace(instance.Namespace), client.MatchingLabels{"app.kubernetes.io/instance": instance.Name})
+	if err != nil {
+		return err
+	}
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if route.Name == instance.Name || keep[route.Name] || !metav1.IsControlledBy(route, instance) {
+			continue
+		}
+		if err := r.DeleteResource(route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newGatewayRoute returns an empty Gateway API route of the given kind with the given name and namespace
+func newGatewayRoute(gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(gvk)
+	route.SetName(objectMeta.Name)
+	route.SetNamespace(objectMeta.Namespace)
+	return route
 }
 
 func getMonitoringEnabledLabelName(ba common.BaseComponent) string {
 	return "monitor." + ba.GetGroupName() + "/enabled"
 }
+
+// updateFinalizer adds the finalizer when the instance uses cert-manager resources that are not deleted with it, and
+// removes it otherwise, as the reconcile deletes the resources that the instance stops using. The finalizer is left as
+// it is while cert-manager cannot be discovered.
+func (r *RuntimeComponentReconciler) updateFinalizer(instance *appstacksv1beta2.RuntimeComponent, finalizerName string) error {
+	defaulted := instance.DeepCopy()
+	defaulted.Initialize()
+	needed := false
+	if appstacksutils.NeedsCertificateCleanup(defaulted, "rco") {
+		ok, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+		if err != nil {
+			return nil
+		}
+		needed = ok
+	}
+	if needed == controllerutil.ContainsFinalizer(instance, finalizerName) {
+		return nil
+	}
+	if needed {
+		controllerutil.AddFinalizer(instance, finalizerName)
+	} else {
+		controllerutil.RemoveFinalizer(instance, finalizerName)
+	}
+	return r.GetClient().Update(context.TODO(), instance)
+}
+
+// cleanupCertificates deletes the cert-manager resources of the instance that are not deleted with it. The clean up is
+// skipped with a warning event when cert-manager cannot be discovered, so that the instance is not stuck in deletion.
+func (r *RuntimeComponentReconciler) cleanupCertificates(instance *appstacksv1beta2.RuntimeComponent) error {
+	if _, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate"); err != nil {
+		r.GetRecorder().Event(instance, corev1.EventTypeWarning, "CleanupSkipped",
+			fmt.Sprintf("Skipped the clean up of the cert-manager resources as %s could not be discovered. Delete the unused resources manually: %v", certmanagerv1.SchemeGroupVersion.String(), err))
+		return nil
+	}
+	if err := r.DeleteSvcCertSecret(instance, "rco"); err != nil {
+		return err
+	}
+	return r.ReconcileRouteCertificate(instance, false)
+}
+
+// getTrustedPeers returns the names of the components of the same application in the namespace that have mutual TLS
+// enabled, and so have a client certificate from the same CA as the instance
+func (r *RuntimeComponentReconciler) getTrustedPeers(instance *appstacksv1beta2.RuntimeComponent) ([]string, error) {
+	if instance.GetStatus().GetReferences()[common.StatusReferenceClientCertSecretName] == "" {
+		return nil, nil
+	}
+	components := &appstacksv1beta2.RuntimeComponentList{}
+	if err := r.GetClient().List(context.TODO(), components, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+	return appstacksutils.GetTrustedPeers(instance, components.Items), nil
+}
@@ -0,0 +1,171 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Kinds of resources that operator versions 0.7.1 and below created for a RuntimeComponent
+var migratedResourceKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "", Version: "v1", Kind: "Service"},
+	{Group: "", Version: "v1", Kind: "ServiceAccount"},
+	{Group: "", Version: "v1", Kind: "Secret"},
+	{Group: "autoscaling", Version: "v1", Kind: "HorizontalPodAutoscaler"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+	{Group: "route.openshift.io", Version: "v1", Kind: "Route"},
+	{Group: "serving.knative.dev", Version: "v1", Kind: "Service"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+}
+
+// RuntimeComponentMigrationReconciler migrates app.stacks/v1beta1 RuntimeComponents to rc.app.stacks/v1beta2
+type RuntimeComponentMigrationReconciler struct {
+	appstacksutils.ReconcilerBase
+	Log logr.Logger
+}
+
+// +kubebuilder:rbac:groups=app.stacks,resources=runtimecomponents,verbs=get;list;watch;update,namespace=runtime-component-operator
+
+// Reconcile creates the v1beta2 equivalent of a v1beta1 RuntimeComponent and moves the ownership of the
+// resources created for the v1beta1 instance to it, so that they are adopted rather than recreated.
+func (r *RuntimeComponentMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
+
+	old := &unstructured.Unstructured{}
+	old.SetGroupVersionKind(appstacksutils.RuntimeComponentV1Beta1GVK)
+	if err := r.GetClient().Get(ctx, req.NamespacedName, old); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if old.GetDeletionTimestamp() != nil || old.GetAnnotations()[appstacksutils.MigratedToAnnotation] != "" {
+		return reconcile.Result{}, nil
+	}
+
+	reqLogger.Info("Migrating RuntimeComponent from " + appstacksutils.RuntimeComponentV1Beta1GVK.GroupVersion().String())
+	converted, dropped, err := appstacksutils.ConvertV1Beta1RuntimeComponent(old)
+	if err != nil {
+		reqLogger.Error(err, "Failed to convert RuntimeComponent")
+		r.GetRecorder().Event(old, corev1.EventTypeWarning, "MigrationFailed", err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	instance := &appstacksv1beta2.RuntimeComponent{}
+	err = r.GetClient().Get(ctx, req.NamespacedName, instance)
+	if kerrors.IsNotFound(err) {
+		instance = converted
+		if err = r.GetClient().Create(ctx, instance); err != nil {
+			return reconcile.Result{}, err
+		}
+	} else if err != nil {
+		return reconcile.Result{}, err
+	} else if instance.Annotations[appstacksutils.MigratedFromAnnotation] == "" {
+		// Never take over resources from a v1beta2 instance the user created on their own
+		msg := fmt.Sprintf("A %s RuntimeComponent with the same name already exists", appstacksv1beta2.GroupVersion.String())
+		reqLogger.Info(msg)
+		r.GetRecorder().Event(old, corev1.EventTypeWarning, "MigrationFailed", msg)
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.adoptResources(ctx, old, instance); err != nil {
+		reqLogger.Error(err, "Failed to adopt resources of the migrated RuntimeComponent")
+		return reconcile.Result{}, err
+	}
+
+	annotations := old.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[appstacksutils.MigratedToAnnotation] = appstacksv1beta2.GroupVersion.String()
+	old.SetAnnotations(annotations)
+	if err := r.GetClient().Update(ctx, old); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	msg := fmt.Sprintf("Migrated to %s. This instance can now be deleted.", appstacksv1beta2.GroupVersion.String())
+	if len(dropped) > 0 {
+		msg += " The following fields are not supported anymore and were not migrated: " + strings.Join(dropped, ", ")
+	}
+	r.GetRecorder().Event(old, corev1.EventTypeNormal, "Migrated", msg)
+	reqLogger.Info(msg)
+	return reconcile.Result{}, nil
+}
+
+// adoptResources replaces the controller reference to the v1beta1 instance on its resources with a reference to the v1beta2 instance
+func (r *RuntimeComponentMigrationReconciler) adoptResources(ctx context.Context, old *unstructured.Unstructured, instance *appstacksv1beta2.RuntimeComponent) error {
+	gvk := appstacksv1beta2.GroupVersion.WithKind("RuntimeComponent")
+	newRef := *metav1.NewControllerRef(instance, gvk)
+
+	for _, kind := range migratedResourceKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+		err := r.GetAPIReader().List(ctx, list, client.InNamespace(old.GetNamespace()), client.MatchingLabels{"app.kubernetes.io/instance": old.GetName()})
+		if err != nil {
+			if meta.IsNoMatchError(err) || kerrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			refs := item.GetOwnerReferences()
+			adopted := false
+			for j := range refs {
+				if refs[j].UID == old.GetUID() {
+					refs[j] = newRef
+					adopted = true
+				}
+			}
+			if !adopted {
+				continue
+			}
+			item.SetOwnerReferences(refs)
+			if err := r.GetClient().Update(ctx, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetupWithManager initializes reconciler. The controller is only started when the v1beta1 API is still served by the cluster.
+func (r *RuntimeComponentMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ok, _ := r.IsGroupVersionSupported(appstacksutils.RuntimeComponentV1Beta1GVK.GroupVersion().String(), appstacksutils.RuntimeComponentV1Beta1GVK.Kind)
+	if !ok {
+		return nil
+	}
+
+	old := &unstructured.Unstructured{}
+	old.SetGroupVersionKind(appstacksutils.RuntimeComponentV1Beta1GVK)
+	return ctrl.NewControllerManagedBy(mgr).Named("runtimecomponent-migration").For(old).Complete(r)
+}
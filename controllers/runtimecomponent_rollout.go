@@ -0,0 +1,115 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateRolloutStatus moves the rollout in status.rollout to its next phase, based on the state of the stable and canary Deployments
+func (r *RuntimeComponentReconciler) updateRolloutStatus(instance *appstacksv1beta2.RuntimeComponent) error {
+	if instance.Spec.Rollout == nil {
+		instance.Status.Rollout = nil
+		return nil
+	}
+
+	deployedImage, stableReady := "", false
+	stable := &appsv1.Deployment{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, stable)
+	if err == nil {
+		if len(stable.Spec.Template.Spec.Containers) > 0 {
+			deployedImage = appstacksutils.GetAppContainer(stable.Spec.Template.Spec.Containers).Image
+		}
+		if instance.Status.Rollout != nil {
+			stableReady = appstacksutils.IsDeploymentReady(stable, instance.Status.Rollout.StableImage)
+		}
+	} else if !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	canaryReady := false
+	canary := &appsv1.Deployment{}
+	err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: appstacksutils.GetCanaryName(instance), Namespace: instance.Namespace}, canary)
+	if err == nil {
+		if instance.Status.Rollout != nil {
+			canaryReady = appstacksutils.IsDeploymentReady(canary, instance.Status.Rollout.CanaryImage)
+		}
+	} else if !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	var oldPhase appstacksv1beta2.RolloutPhase
+	if instance.Status.Rollout != nil {
+		oldPhase = instance.Status.Rollout.Phase
+	}
+	appstacksutils.AdvanceRollout(instance, deployedImage, stableReady, canaryReady, metav1.Now())
+
+	rollout := instance.Status.Rollout
+	if oldPhase != "" && rollout.Phase != oldPhase {
+		eventType := corev1.EventTypeNormal
+		if rollout.Phase == appstacksv1beta2.RolloutPhaseAborted {
+			eventType = corev1.EventTypeWarning
+		}
+		msg := rollout.Message
+		if rollout.Phase == appstacksv1beta2.RolloutPhaseProgressing {
+			msg = "Rolling out " + rollout.CanaryImage
+		}
+		r.GetRecorder().Event(instance, eventType, "Rollout"+string(rollout.Phase), msg)
+	}
+	return nil
+}
+
+// reconcileCanary creates the canary Deployment and Service while a rollout is in progress, and deletes them otherwise
+func (r *RuntimeComponentReconciler) reconcileCanary(instance *appstacksv1beta2.RuntimeComponent) error {
+	canaryMeta := metav1.ObjectMeta{Name: appstacksutils.GetCanaryName(instance), Namespace: instance.Namespace}
+	deploy := &appsv1.Deployment{ObjectMeta: canaryMeta}
+	svc := &corev1.Service{ObjectMeta: canaryMeta}
+
+	if !appstacksutils.IsCanaryActive(instance) {
+		return r.DeleteResources([]client.Object{deploy, svc})
+	}
+
+	err := r.CreateOrUpdate(deploy, instance, func() error {
+		appstacksutils.CustomizeDeployment(deploy, instance)
+		appstacksutils.CustomizePodSpec(&deploy.Spec.Template, instance)
+		if err := appstacksutils.CustomizePodWithSVCCertificate(&deploy.Spec.Template, instance, r.GetClient()); err != nil {
+			return err
+		}
+		if err := appstacksutils.CustomizePodWithBindings(&deploy.Spec.Template, instance, r.GetClient()); err != nil {
+			return err
+		}
+		appstacksutils.CustomizeCanaryDeployment(deploy, instance, instance.Status.Rollout.CanaryImage)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.CreateOrUpdate(svc, instance, func() error {
+		appstacksutils.CustomizeCanaryService(svc, instance)
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	appstacksutils "github.com/application-stacks/runtime-component-operator/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// RuntimeComponentWebhook defaults and validates RuntimeComponent resources at admission time
+type RuntimeComponentWebhook struct{}
+
+// +kubebuilder:webhook:path=/mutate-rc-app-stacks-v1beta2-runtimecomponent,mutating=true,failurePolicy=fail,sideEffects=None,groups=rc.app.stacks,resources=runtimecomponents,verbs=create;update,versions=v1beta2,name=mruntimecomponent.rc.app.stacks,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-rc-app-stacks-v1beta2-runtimecomponent,mutating=false,failurePolicy=fail,sideEffects=None,groups=rc.app.stacks,resources=runtimecomponents,verbs=create;update,versions=v1beta2,name=vruntimecomponent.rc.app.stacks,admissionReviewVersions=v1
+
+var _ admission.CustomDefaulter = &RuntimeComponentWebhook{}
+var _ admission.CustomValidator = &RuntimeComponentWebhook{}
+
+// SetupWebhookWithManager registers the webhook with the manager's webhook server
+func (w *RuntimeComponentWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&appstacksv1beta2.RuntimeComponent{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default implements admission.CustomDefaulter. It only persists spec.replicas, which the scale
+// subresource needs. The other defaults are applied in memory by the reconciler, so that the stored
+// spec keeps matching the manifest of the user.
+func (w *RuntimeComponentWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*appstacksv1beta2.RuntimeComponent)
+	if !ok {
+		return fmt.Errorf("expected a RuntimeComponent but got a %T", obj)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	instance.InitializeReplicas()
+	return nil
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (w *RuntimeComponentWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return w.validate(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (w *RuntimeComponentWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return w.validate(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator
+func (w *RuntimeComponentWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (w *RuntimeComponentWebhook) validate(obj runtime.Object) error {
+	instance, ok := obj.(*appstacksv1beta2.RuntimeComponent)
+	if !ok {
+		return fmt.Errorf("expected a RuntimeComponent but got a %T", obj)
+	}
+	// Do not block updates, such as finalizer removal, on an instance that is being deleted
+	if instance.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	// Validate the spec as the reconciler will see it, with defaults applied
+	instance = instance.DeepCopy()
+	instance.Initialize()
+	_, err := appstacksutils.ValidateAdmission(instance)
+	return err
+}
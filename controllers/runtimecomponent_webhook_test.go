@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWebhookDefault(t *testing.T) {
+	w := &RuntimeComponentWebhook{}
+	enabled := true
+	replicas := int32(3)
+	deleted := metav1.Now()
+
+	tests := []struct {
+		name         string
+		instance     *appstacksv1beta2.RuntimeComponent
+		wantReplicas int32
+	}{
+		{
+			name: "replicas are defaulted",
+			instance: &appstacksv1beta2.RuntimeComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test"},
+				Spec:       appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: "my-image"},
+			},
+			wantReplicas: 1,
+		},
+		{
+			name: "set replicas are kept",
+			instance: &appstacksv1beta2.RuntimeComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test"},
+				Spec:       appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: "my-image", Replicas: &replicas},
+			},
+			wantReplicas: 3,
+		},
+		{
+			name: "no replicas with autoscaling",
+			instance: &appstacksv1beta2.RuntimeComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test"},
+				Spec: appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: "my-image",
+					Autoscaling: &appstacksv1beta2.RuntimeComponentAutoScaling{MaxReplicas: 3}},
+			},
+		},
+		{
+			name: "no replicas with a Knative service",
+			instance: &appstacksv1beta2.RuntimeComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test"},
+				Spec:       appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: "my-image", CreateKnativeService: &enabled},
+			},
+		},
+		{
+			name: "instance being deleted is not defaulted",
+			instance: &appstacksv1beta2.RuntimeComponent{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test", DeletionTimestamp: &deleted},
+				Spec:       appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: "my-image"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := w.Default(context.TODO(), tt.instance); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+			spec := tt.instance.Spec
+			if got := spec.Replicas; (got == nil && tt.wantReplicas != 0) || (got != nil && *got != tt.wantReplicas) {
+				t.Errorf("spec.replicas = %v, want %d", got, tt.wantReplicas)
+			}
+			// The other defaults are only applied in memory by the reconciler
+			if spec.PullPolicy != nil || spec.ApplicationName != "" || spec.Service != nil {
+				t.Errorf("Default() persisted in-memory defaults: pullPolicy %v, applicationName %q, service %v", spec.PullPolicy, spec.ApplicationName, spec.Service)
+			}
+		})
+	}
+
+	if err := w.Default(context.TODO(), &corev1.Pod{}); err == nil {
+		t.Error("Default() of a Pod succeeded, want an error")
+	}
+}
+
+func TestWebhookValidate(t *testing.T) {
+	w := &RuntimeComponentWebhook{}
+	minReplicas := int32(3)
+	deleted := metav1.Now()
+
+	valid := &appstacksv1beta2.RuntimeComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "test"},
+		Spec:       appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: "my-image"},
+	}
+	invalid := valid.DeepCopy()
+	invalid.Spec.Autoscaling = &appstacksv1beta2.RuntimeComponentAutoScaling{MinReplicas: &minReplicas, MaxReplicas: 1}
+	deleting := invalid.DeepCopy()
+	deleting.DeletionTimestamp = &deleted
+	deleting.Finalizers = []string{"rc.app.stacks/finalizer"}
+	finalizerRemoved := deleting.DeepCopy()
+	finalizerRemoved.Finalizers = nil
+
+	tests := []struct {
+		name     string
+		validate func() error
+		wantErr  bool
+	}{
+		{"create valid", func() error { return w.ValidateCreate(context.TODO(), valid) }, false},
+		{"create invalid", func() error { return w.ValidateCreate(context.TODO(), invalid) }, true},
+		{"update to valid", func() error { return w.ValidateUpdate(context.TODO(), invalid, valid) }, false},
+		{"update to invalid", func() error { return w.ValidateUpdate(context.TODO(), valid, invalid) }, true},
+		{"update of instance being deleted", func() error { return w.ValidateUpdate(context.TODO(), deleting, finalizerRemoved) }, false},
+		{"delete invalid", func() error { return w.ValidateDelete(context.TODO(), invalid) }, false},
+		{"create of other kind", func() error { return w.ValidateCreate(context.TODO(), &corev1.Pod{}) }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if valid.Spec.Service != nil {
+		t.Errorf("validation persisted the defaults, spec.service = %v", valid.Spec.Service)
+	}
+}
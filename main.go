@@ -24,9 +24,11 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
@@ -63,9 +65,12 @@ func init() {
 }
 
 func main() {
-	//var metricsAddr string
+	var metricsAddr string
+	var probeAddr string
 	var enableLeaderElection bool
-	//flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&metricsAddr, "metrics-addr", "0", "The address the metric endpoint binds to, such as :8080. "+
+		"The metric endpoint is disabled when set to 0.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the health and readiness probe endpoints bind to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -84,27 +89,48 @@ func main() {
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:             scheme,
-		MetricsBindAddress: "0",
-		Port:               9443,
-		LeaderElection:     enableLeaderElection,
-		LeaderElectionID:   "c407d44e.rc.app.stacks",
-		LeaseDuration:      &leaseDuration,
-		RenewDeadline:      &renewDeadline,
-		Namespace:          watchNamespace,
+		Scheme:                 scheme,
+		MetricsBindAddress:     metricsAddr,
+		HealthProbeBindAddress: probeAddr,
+		Port:                   9443,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "c407d44e.rc.app.stacks",
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		Namespace:              watchNamespace,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	// The reconcilers share the discovered APIs
+	discoveryCache := utils.NewDiscoveryCache(discoveryClient, utils.DefaultDiscoveryTTL)
+	newReconcilerBase := func() utils.ReconcilerBase {
+		base := utils.NewReconcilerBase(mgr.GetAPIReader(), mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("runtime-component-operator"))
+		base.SetDiscoveryCache(discoveryCache)
+		return base
+	}
+
 	if err = (&controllers.RuntimeComponentReconciler{
-		ReconcilerBase: utils.NewReconcilerBase(mgr.GetAPIReader(), mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("runtime-component-operator")),
+		ReconcilerBase: newReconcilerBase(),
 		Log:            ctrl.Log.WithName("controllers").WithName("RuntimeComponent"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RuntimeComponent")
 		os.Exit(1)
 	}
+	if err = (&controllers.RuntimeComponentMigrationReconciler{
+		ReconcilerBase: newReconcilerBase(),
+		Log:            ctrl.Log.WithName("controllers").WithName("RuntimeComponentMigration"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RuntimeComponentMigration")
+		os.Exit(1)
+	}
 	if err = (&controllers.RuntimeOperationReconciler{
 		Client:     mgr.GetClient(),
 		Log:        ctrl.Log.WithName("controllers").WithName("RuntimeOperation"),
@@ -115,8 +141,28 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "RuntimeOperation")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = (&controllers.RuntimeComponentWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RuntimeComponent")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	healthBase := newReconcilerBase()
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("informers", utils.CacheSyncCheck(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up ready check", "check", "informers")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("discovery", healthBase.DiscoveryCheck()); err != nil {
+		setupLog.Error(err, "unable to set up ready check", "check", "discovery")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// applyConfiguration returns the body of the server-side apply request for obj, which holds the desired state built from
+// scratch by the reconcile function. The body only has the fields that the operator owns. Typed objects serialize the
+// fields without omitempty even when the operator does not set them, such as empty structs and null timestamps, and
+// they are removed from the body. The zero values that the reconcile function sets are kept in the body: the fields
+// behind pointers, and the zeroFields, which the reconcile function sets over a non-zero current value. Unstructured
+// objects only contain the fields that the operator sets and are applied as is.
+func applyConfiguration(obj client.Object, gvk schema.GroupVersionKind, zeroFields []zeroField) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		u.SetGroupVersionKind(gvk)
+		return u, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	removeZeroFields(content, reflect.ValueOf(obj).Elem())
+	for _, f := range zeroFields {
+		if _, found, _ := unstructured.NestedFieldNoCopy(content, f.path...); !found {
+			unstructured.SetNestedField(content, f.value, f.path...)
+		}
+	}
+	delete(content, "status")
+	body := &unstructured.Unstructured{Object: content}
+	body.SetGroupVersionKind(gvk)
+	return body, nil
+}
+
+// zeroField is a field that the reconcile function sets to its zero value
+type zeroField struct {
+	path  []string
+	value interface{}
+}
+
+// findZeroWrites runs the reconcile function on the current state of obj, and returns the fields that it sets to their
+// zero value over a non-zero current value. The zero values of typed objects cannot be told apart from unset fields in
+// the desired state built from scratch.
+func findZeroWrites(obj client.Object, current client.Object, reconcile func() error) ([]zeroField, error) {
+	v := reflect.ValueOf(obj).Elem()
+	v.Set(reflect.ValueOf(current.DeepCopyObject()).Elem())
+	if err := reconcile(); err != nil {
+		return nil, err
+	}
+	return zeroWrites(reflect.ValueOf(current).Elem(), v, nil), nil
+}
+
+// zeroWrites returns the scalar fields of the struct updated that are zero and non-zero in the struct current. The
+// fields of lists and maps are not compared, as their items are not matched by path.
+func zeroWrites(current reflect.Value, updated reflect.Value, path []string) []zeroField {
+	fields := []zeroField{}
+	t := updated.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, inline, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		fieldPath := path
+		if !inline {
+			fieldPath = append(append([]string{}, path...), name)
+		}
+		cv, uv := current.Field(i), updated.Field(i)
+		for cv.Kind() == reflect.Ptr && !cv.IsNil() && !uv.IsNil() {
+			cv, uv = cv.Elem(), uv.Elem()
+		}
+		switch uv.Kind() {
+		case reflect.Struct:
+			if !uv.Type().Implements(jsonMarshalerType) && !reflect.PtrTo(uv.Type()).Implements(jsonMarshalerType) {
+				fields = append(fields, zeroWrites(cv, uv, fieldPath)...)
+			}
+		default:
+			if zero, ok := zeroValue(uv); ok && !cv.IsZero() && uv.IsZero() {
+				fields = append(fields, zeroField{path: fieldPath, value: zero})
+			}
+		}
+	}
+	return fields
+}
+
+// zeroValue returns the serialized zero value of the scalar v
+func zeroValue(v reflect.Value) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Bool:
+		return false, true
+	case reflect.String:
+		return "", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(0), true
+	case reflect.Float32, reflect.Float64:
+		return float64(0), true
+	}
+	return nil, false
+}
+
+// jsonFieldName returns the JSON name of a struct field and whether its fields are inlined in the parent. It returns
+// false for the fields that are not serialized.
+func jsonFieldName(field reflect.StructField) (name string, inline bool, ok bool) {
+	if field.PkgPath != "" {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	name = strings.Split(tag, ",")[0]
+	if name == "-" {
+		return "", false, false
+	}
+	if strings.Contains(tag, ",inline") || (field.Anonymous && name == "") {
+		return "", true, true
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, false, true
+}
+
+// removeZeroFields removes the fields of the struct v that have their zero value from the serialized struct
+func removeZeroFields(content map[string]interface{}, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, inline, ok := jsonFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if inline {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				removeZeroFields(content, fv)
+			}
+			continue
+		}
+		if fv.IsZero() {
+			delete(content, name)
+			continue
+		}
+		removeNestedZeroFields(content[name], fv)
+	}
+}
+
+// removeNestedZeroFields removes the zero fields of the structs nested in the value v from its serialized value.
+// Values with their own JSON serialization, such as quantities and timestamps, are left as is.
+func removeNestedZeroFields(content interface{}, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(jsonMarshalerType) || reflect.PtrTo(v.Type()).Implements(jsonMarshalerType) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if m, ok := content.(map[string]interface{}); ok {
+			removeZeroFields(m, v)
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := content.([]interface{}); ok && len(items) == v.Len() {
+			for i := range items {
+				removeNestedZeroFields(items[i], v.Index(i))
+			}
+		}
+	case reflect.Map:
+		if m, ok := content.(map[string]interface{}); ok && v.Type().Key().Kind() == reflect.String {
+			iter := v.MapRange()
+			for iter.Next() {
+				removeNestedZeroFields(m[iter.Key().String()], iter.Value())
+			}
+		}
+	}
+}
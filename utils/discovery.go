@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// DefaultDiscoveryTTL is how long the discovered resources of an API group version are cached
+const DefaultDiscoveryTTL = 5 * time.Minute
+
+// DiscoveryCache caches the resources of the API group versions discovered on the cluster, so that the checks of the
+// APIs in every reconcile do not query the API server. The resources of a group version, or the fact that it is not
+// installed, are cached until they expire or are invalidated. Discovery failures are not cached.
+type DiscoveryCache struct {
+	client discovery.DiscoveryInterface
+	ttl    time.Duration
+
+	mutex   sync.RWMutex
+	entries map[string]discoveryEntry
+	// generation changes on every invalidation, so that a lookup started before it is not cached
+	generation int64
+}
+
+type discoveryEntry struct {
+	resources *metav1.APIResourceList
+	err       error
+	expiry    time.Time
+}
+
+// NewDiscoveryCache creates a DiscoveryCache that caches the group versions discovered with the client for the TTL
+func NewDiscoveryCache(client discovery.DiscoveryInterface, ttl time.Duration) *DiscoveryCache {
+	return &DiscoveryCache{
+		client:  client,
+		ttl:     ttl,
+		entries: map[string]discoveryEntry{},
+	}
+}
+
+// GetTTL returns how long the discovered group versions are cached
+func (c *DiscoveryCache) GetTTL() time.Duration {
+	return c.ttl
+}
+
+// ServerResourcesForGroupVersion returns the resources of a group version, or a NotFound error when the group version
+// is not installed on the cluster
+func (c *DiscoveryCache) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	c.mutex.RLock()
+	entry, found := c.entries[groupVersion]
+	generation := c.generation
+	c.mutex.RUnlock()
+	if found && time.Now().Before(entry.expiry) {
+		return entry.resources, entry.err
+	}
+
+	resources, err := c.client.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.generation == generation {
+		c.entries[groupVersion] = discoveryEntry{resources: resources, err: err, expiry: time.Now().Add(c.ttl)}
+	}
+	return resources, err
+}
+
+// Invalidate removes the cached group versions of the given API groups, or all the cached group versions when no group is given
+func (c *DiscoveryCache) Invalidate(groups ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	if len(groups) == 0 {
+		c.entries = map[string]discoveryEntry{}
+		return
+	}
+	for groupVersion := range c.entries {
+		if gv, err := schema.ParseGroupVersion(groupVersion); err != nil || ContainsString(groups, gv.Group) {
+			delete(c.entries, groupVersion)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Errors that classify why a reconcile failed. They are wrapped by the errors returned by the reconcile steps.
+var (
+	// ErrValidation is wrapped by the errors of invalid RuntimeComponent specs
+	ErrValidation = errors.New("validation failed")
+	// ErrNotSupported is wrapped by the errors of features that need an API which is not installed on the cluster
+	ErrNotSupported = errors.New("not supported on the cluster")
+	// ErrNotReady is wrapped by the errors of resources that the application depends on and are not ready yet
+	ErrNotReady = errors.New("not ready")
+)
+
+// Reasons of the failed reconciles that are not caused by the API server
+const (
+	ReasonValidationFailed   = "ValidationFailed"
+	ReasonDiscoveryFailed    = "DiscoveryFailed"
+	ReasonAPINotSupported    = "APINotSupported"
+	ReasonDependencyNotReady = "DependencyNotReady"
+)
+
+// DiscoveryError is returned when the APIs installed on the cluster cannot be discovered
+type DiscoveryError struct {
+	GroupVersion string
+	Err          error
+}
+
+func (e *DiscoveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DiscoveryError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorReason returns the reason why a reconcile failed with err: the class of the error, or the reason of the
+// API server status. It returns an empty reason for other errors.
+func ErrorReason(err error) string {
+	var discoveryErr *DiscoveryError
+	switch {
+	case errors.Is(err, ErrValidation):
+		return ReasonValidationFailed
+	case errors.As(err, &discoveryErr):
+		return ReasonDiscoveryFailed
+	case errors.Is(err, ErrNotSupported):
+		return ReasonAPINotSupported
+	case errors.Is(err, ErrNotReady):
+		return ReasonDependencyNotReady
+	}
+	return string(apierrors.ReasonForError(err))
+}
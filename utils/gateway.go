@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+	routev1 "github.com/openshift/api/route/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Gateway API kinds used to expose applications
+var (
+	GatewayGVK   = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+	HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	TLSRouteGVK  = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TLSRoute"}
+)
+
+// gatewayTimeout bounds the reads of the Gateways that applications attach to from the API server
+const gatewayTimeout = 10 * time.Second
+
+// GetParentGateway returns the Gateway that the routes of the application attach to, from spec.route.gateway or from the operator configuration.
+// The name is empty when the application is not exposed through a Gateway.
+func GetParentGateway(ba common.BaseComponent) (name string, namespace string, sectionName string) {
+	obj := ba.(metav1.Object)
+	if ba.GetRoute() != nil && ba.GetRoute().GetGateway() != nil {
+		gw := ba.GetRoute().GetGateway()
+		name, namespace, sectionName = gw.GetName(), gw.GetNamespace(), gw.GetSectionName()
+	} else {
+		cfg := common.GetConfig()
+		name, namespace = cfg.GatewayName, cfg.GatewayNamespace
+	}
+	if name != "" && namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	return name, namespace, sectionName
+}
+
+// UseTLSRoute returns whether the application is exposed with a TLSRoute, which passes TLS connections through to the application
+func UseTLSRoute(ba common.BaseComponent) bool {
+	rt := ba.GetRoute()
+	return rt != nil && rt.GetTermination() != nil && *rt.GetTermination() == routev1.TLSTerminationPassthrough
+}
+
+// CustomizeHTTPRoute customizes a Gateway API HTTPRoute that sends the traffic of the route hosts and paths to the application Service.
+// The hostnames of an HTTPRoute apply to all its rules, so every path is served on every host.
+func CustomizeHTTPRoute(route *unstructured.Unstructured, ba common.BaseComponent) {
+	paths := GetRoutePaths(ba)
+	hosts := []string{}
+	rules := []interface{}{}
+	for _, p := range paths {
+		if p.Host != "" && !ContainsString(hosts, p.Host) {
+			hosts = append(hosts, p.Host)
+		}
+		path, pathType := p.Path, "PathPrefix"
+		if path == "" {
+			path = "/"
+		}
+		if p.PathType == networkingv1.PathTypeExact {
+			pathType = "Exact"
+		}
+		rules = append(rules, map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":  pathType,
+						"value": path,
+					},
+				},
+			},
+			"backendRefs": []interface{}{gatewayBackendRef(ba.(metav1.Object).GetName(), int64(p.PortNumber), 1)},
+		})
+	}
+	customizeGatewayRoute(route, HTTPRouteGVK, ba, hosts)
+	unstructured.SetNestedSlice(route.Object, rules, "spec", "rules")
+}
+
+// CustomizeTLSRoute customizes a Gateway API TLSRoute that passes the TLS connections for the route host through to the primary port of the application Service
+func CustomizeTLSRoute(route *unstructured.Unstructured, ba common.BaseComponent) {
+	primary := GetRoutePaths(ba)[0]
+	hosts := []string{}
+	if primary.Host != "" {
+		hosts = append(hosts, primary.Host)
+	}
+	customizeGatewayRoute(route, TLSRouteGVK, ba, hosts)
+
+	unstructured.SetNestedSlice(route.Object, []interface{}{
+		map[string]interface{}{
+			"backendRefs": []interface{}{gatewayBackendRef(ba.(metav1.Object).GetName(), int64(primary.PortNumber), 1)},
+		},
+	}, "spec", "rules")
+}
+
+// customizeGatewayRoute sets the metadata, parent Gateway and hostnames shared by HTTPRoutes and TLSRoutes
+func customizeGatewayRoute(route *unstructured.Unstructured, gvk schema.GroupVersionKind, ba common.BaseComponent, hosts []string) {
+	route.SetGroupVersionKind(gvk)
+	route.SetLabels(ba.GetLabels())
+	annotations := MergeMaps(route.GetAnnotations(), ba.GetAnnotations())
+	if rt := ba.GetRoute(); rt != nil {
+		annotations = MergeMaps(annotations, rt.GetAnnotations())
+	}
+	route.SetAnnotations(annotations)
+
+	name, namespace, sectionName := GetParentGateway(ba)
+	parentRef := map[string]interface{}{
+		"group":     GatewayGVK.Group,
+		"kind":      GatewayGVK.Kind,
+		"name":      name,
+		"namespace": namespace,
+	}
+	if sectionName != "" {
+		parentRef["sectionName"] = sectionName
+	}
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+	}
+	if len(hosts) > 0 {
+		hostnames := make([]interface{}, len(hosts))
+		for i := range hosts {
+			hostnames[i] = hosts[i]
+		}
+		spec["hostnames"] = hostnames
+	}
+	route.Object["spec"] = spec
+}
+
+// gatewayBackendRef returns a reference to the given port of a Service
+func gatewayBackendRef(serviceName string, port int64, weight int32) map[string]interface{} {
+	return map[string]interface{}{
+		"group":  "",
+		"kind":   "Service",
+		"name":   serviceName,
+		"port":   port,
+		"weight": int64(weight),
+	}
+}
+
+// UseGatewayAPI returns whether the application is exposed with Gateway API routes. It returns an error when
+// spec.route.gateway is set but the Gateway API is not available. A Gateway from the operator configuration is
+// only used when the Gateway API is available.
+func (r *ReconcilerBase) UseGatewayAPI(ba common.BaseComponent) (bool, error) {
+	if name, _, _ := GetParentGateway(ba); name == "" {
+		return false, nil
+	}
+	gvk := HTTPRouteGVK
+	if UseTLSRoute(ba) {
+		gvk = TLSRouteGVK
+	}
+	ok, err := r.IsGroupVersionSupported(gvk.GroupVersion().String(), gvk.Kind)
+	if err != nil {
+		return false, err
+	}
+	if !ok && ba.GetRoute() != nil && ba.GetRoute().GetGateway() != nil {
+		return false, fmt.Errorf("failed to expose the application through Gateway %s as %s %s is %w", ba.GetRoute().GetGateway().GetName(), gvk.GroupVersion().String(), gvk.Kind, ErrNotSupported)
+	}
+	return ok, nil
+}
+
+// getGatewayRouteInfo returns the host, path and protocol of the Gateway API route of the application. The protocol of
+// an HTTPRoute is the one recorded in status by ReconcileGatewayProtocol.
+func (r *ReconcilerBase) getGatewayRouteInfo(ba common.BaseComponent) (host string, path string, protocol string) {
+	mObj := ba.(metav1.Object)
+	key := types.NamespacedName{Name: mObj.GetName(), Namespace: mObj.GetNamespace()}
+	route := &unstructured.Unstructured{}
+	if UseTLSRoute(ba) {
+		route.SetGroupVersionKind(TLSRouteGVK)
+	} else {
+		route.SetGroupVersionKind(HTTPRouteGVK)
+	}
+	if err := r.GetClient().Get(context.Background(), key, route); err != nil {
+		return "", "", "http"
+	}
+
+	if hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); len(hostnames) > 0 {
+		host = hostnames[0]
+	}
+	if UseTLSRoute(ba) {
+		return host, "", "https"
+	}
+	if rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules"); len(rules) > 0 {
+		if rule, ok := rules[0].(map[string]interface{}); ok {
+			if matches, _, _ := unstructured.NestedSlice(rule, "matches"); len(matches) > 0 {
+				if match, ok := matches[0].(map[string]interface{}); ok {
+					path, _, _ = unstructured.NestedString(match, "path", "value")
+				}
+			}
+		}
+	}
+	protocol = ba.GetStatus().GetReferences()[common.StatusReferenceGatewayProtocol]
+	if protocol == "" {
+		protocol = "http"
+	}
+	return host, path, protocol
+}
+
+// ReconcileGatewayProtocol records in status the protocol of the Gateway listeners that the HTTPRoute of the application
+// attaches to, which is used for the endpoints of the application. The record is removed when the application is not
+// exposed through an HTTPRoute.
+func (r *ReconcilerBase) ReconcileGatewayProtocol(ba common.BaseComponent, exposed bool) error {
+	if !exposed || UseTLSRoute(ba) {
+		delete(ba.GetStatus().GetReferences(), common.StatusReferenceGatewayProtocol)
+		return nil
+	}
+	protocol, err := r.getGatewayProtocol(ba)
+	if err != nil {
+		return err
+	}
+	ba.GetStatus().SetReference(common.StatusReferenceGatewayProtocol, protocol)
+	return nil
+}
+
+// getGatewayProtocol returns https when the Gateway listeners that the application attaches to terminate TLS.
+// The Gateway is usually shared and in another namespace, so it is read from the API server instead of the cache.
+func (r *ReconcilerBase) getGatewayProtocol(ba common.BaseComponent) (string, error) {
+	name, namespace, sectionName := GetParentGateway(ba)
+	gw := &unstructured.Unstructured{}
+	gw.SetGroupVersionKind(GatewayGVK)
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayTimeout)
+	defer cancel()
+	if err := r.GetAPIReader().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, gw); err != nil {
+		if apierrors.IsForbidden(err) {
+			return "", fmt.Errorf("failed to get Gateway %s/%s, the operator needs permission to get Gateways in namespace %s: %w", namespace, name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to get Gateway %s/%s: %w", namespace, name, err)
+	}
+	listeners, _, _ := unstructured.NestedSlice(gw.Object, "spec", "listeners")
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if sectionName != "" && listener["name"] != sectionName {
+			continue
+		}
+		if listener["protocol"] == "HTTPS" {
+			return "https", nil
+		}
+	}
+	return "http", nil
+}
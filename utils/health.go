@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// cacheSyncTimeout is how long the readiness check waits for the informer caches to sync
+const cacheSyncTimeout = time.Second
+
+// OptionalAPIs are the APIs used by the features of the operator that depend on what is installed on the cluster
+var OptionalAPIs = []schema.GroupVersionKind{
+	routev1.SchemeGroupVersion.WithKind("Route"),
+	servingv1.SchemeGroupVersion.WithKind("Service"),
+	prometheusv1.SchemeGroupVersion.WithKind("ServiceMonitor"),
+	certmanagerv1.SchemeGroupVersion.WithKind("Certificate"),
+}
+
+// CheckDiscovery returns an error when the discovery of any of the optional APIs fails, as the features that depend on
+// them would otherwise be skipped. An API that is not installed on the cluster is not an error.
+func (r *ReconcilerBase) CheckDiscovery() error {
+	failures := []string{}
+	for _, gvk := range OptionalAPIs {
+		if _, err := r.IsGroupVersionSupported(gvk.GroupVersion().String(), gvk.Kind); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", gvk.GroupVersion().String(), err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to discover the optional APIs %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// DiscoveryCheck returns a readiness check that fails with the cause when the discovery of the optional APIs fails
+func (r *ReconcilerBase) DiscoveryCheck() healthz.Checker {
+	return func(_ *http.Request) error {
+		return r.CheckDiscovery()
+	}
+}
+
+// CacheSyncCheck returns a readiness check that fails until the informer caches of the manager have synced
+func CacheSyncCheck(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches have not synced")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/application-stacks/runtime-component-operator/common"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsPrefix = "runtime_component_"
+
+var (
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "reconcile_errors_total",
+		Help: "Number of failed reconciles of RuntimeComponents, by reason of the error.",
+	}, []string{"reason"})
+
+	timeToReady = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    metricsPrefix + "time_to_ready_seconds",
+		Help:    "Time from the creation or the spec change of a RuntimeComponent until it is ready.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	})
+
+	componentMetrics = newComponentCollector()
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileErrors, timeToReady, componentMetrics)
+}
+
+// componentState is the state of a RuntimeComponent reported in the metrics
+type componentState struct {
+	ready          bool
+	resourcesReady bool
+	generation     int64
+	changedAt      time.Time
+	waitingReady   bool
+	resources      map[generatedResource]bool
+}
+
+// generatedResource identifies a resource generated for a RuntimeComponent
+type generatedResource struct {
+	kind string
+	key  types.NamespacedName
+}
+
+// componentCollector collects the readiness of the RuntimeComponents and the number of resources generated for them
+type componentCollector struct {
+	mutex      sync.Mutex
+	components map[types.NamespacedName]*componentState
+
+	readyDesc          *prometheus.Desc
+	resourcesReadyDesc *prometheus.Desc
+	resourcesDesc      *prometheus.Desc
+}
+
+func newComponentCollector() *componentCollector {
+	return &componentCollector{
+		components: map[types.NamespacedName]*componentState{},
+		readyDesc: prometheus.NewDesc(metricsPrefix+"ready",
+			"Whether the RuntimeComponent is ready (1) or not (0).", []string{"namespace", "name"}, nil),
+		resourcesReadyDesc: prometheus.NewDesc(metricsPrefix+"resources_ready",
+			"Whether the resources of the RuntimeComponent are ready (1) or not (0).", []string{"namespace", "name"}, nil),
+		resourcesDesc: prometheus.NewDesc(metricsPrefix+"generated_resources",
+			"Number of resources generated for RuntimeComponents, by kind.", []string{"kind"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *componentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.readyDesc
+	ch <- c.resourcesReadyDesc
+	ch <- c.resourcesDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *componentCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	kinds := map[string]int{}
+	for key, state := range c.components {
+		ch <- prometheus.MustNewConstMetric(c.readyDesc, prometheus.GaugeValue, boolToFloat(state.ready), key.Namespace, key.Name)
+		ch <- prometheus.MustNewConstMetric(c.resourcesReadyDesc, prometheus.GaugeValue, boolToFloat(state.resourcesReady), key.Namespace, key.Name)
+		for resource := range state.resources {
+			kinds[resource.kind]++
+		}
+	}
+	for kind, count := range kinds {
+		ch <- prometheus.MustNewConstMetric(c.resourcesDesc, prometheus.GaugeValue, float64(count), kind)
+	}
+}
+
+// getState returns the state of a RuntimeComponent. The caller must hold the mutex.
+func (c *componentCollector) getState(key types.NamespacedName) *componentState {
+	state, found := c.components[key]
+	if !found {
+		state = &componentState{resources: map[generatedResource]bool{}}
+		c.components[key] = state
+	}
+	return state
+}
+
+// observeStatus records the readiness of a RuntimeComponent, and the time it took to become ready after it was created
+// or its spec changed. The time is not known for a component that was changed before the operator started, as the
+// operator only sees its current generation.
+func (c *componentCollector) observeStatus(ba common.BaseComponent) {
+	obj := ba.(client.Object)
+	ready, resourcesReady := isConditionTrue(ba, common.StatusConditionTypeReady), isConditionTrue(ba, common.StatusConditionTypeResourcesReady)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	state := c.getState(client.ObjectKeyFromObject(obj))
+	if state.generation != obj.GetGeneration() {
+		if state.generation != 0 {
+			state.changedAt, state.waitingReady = time.Now(), true
+		} else if obj.GetGeneration() == 1 {
+			state.changedAt, state.waitingReady = obj.GetCreationTimestamp().Time, true
+		}
+		state.generation = obj.GetGeneration()
+	}
+	if ready && state.waitingReady {
+		timeToReady.Observe(time.Since(state.changedAt).Seconds())
+		state.waitingReady = false
+	}
+	state.ready, state.resourcesReady = ready, resourcesReady
+}
+
+// observeResource records a resource generated for a RuntimeComponent
+func (c *componentCollector) observeResource(owner client.Object, obj client.Object, gvk schema.GroupVersionKind) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	resource := generatedResource{kind: gvk.GroupKind().String(), key: client.ObjectKeyFromObject(obj)}
+	c.getState(client.ObjectKeyFromObject(owner)).resources[resource] = true
+}
+
+// forgetResource removes a deleted resource from the resources of the RuntimeComponents of its namespace
+func (c *componentCollector) forgetResource(obj client.Object, gvk schema.GroupVersionKind) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	resource := generatedResource{kind: gvk.GroupKind().String(), key: client.ObjectKeyFromObject(obj)}
+	for key, state := range c.components {
+		if key.Namespace == obj.GetNamespace() {
+			delete(state.resources, resource)
+		}
+	}
+}
+
+// DeleteComponentMetrics stops reporting the metrics of a RuntimeComponent that is deleted
+func DeleteComponentMetrics(key types.NamespacedName) {
+	componentMetrics.mutex.Lock()
+	defer componentMetrics.mutex.Unlock()
+	delete(componentMetrics.components, key)
+}
+
+// recordReconcileError counts a failed reconcile by the reason of its error
+func recordReconcileError(reason string) {
+	if reason == "" {
+		reason = "Unknown"
+	}
+	reconcileErrors.WithLabelValues(reason).Inc()
+}
+
+// recordGeneratedResource counts a resource generated for a RuntimeComponent. Resources of other owners are not counted.
+func recordGeneratedResource(owner interface{}, obj client.Object, gvk schema.GroupVersionKind) {
+	ownerObj, ok := owner.(client.Object)
+	if _, isComponent := owner.(common.BaseComponent); !ok || !isComponent {
+		return
+	}
+	componentMetrics.observeResource(ownerObj, obj, gvk)
+}
+
+func isConditionTrue(ba common.BaseComponent, conditionType common.StatusConditionType) bool {
+	condition := ba.GetStatus().GetCondition(conditionType)
+	return condition != nil && condition.GetStatus() == corev1.ConditionTrue
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// String constants
+const (
+	// MigratedFromAnnotation is set on a v1beta2 RuntimeComponent created from a v1beta1 instance
+	MigratedFromAnnotation = "rc.app.stacks/migrated-from"
+	// MigratedToAnnotation is set on a v1beta1 RuntimeComponent once it has been migrated
+	MigratedToAnnotation = "rc.app.stacks/migrated-to"
+)
+
+// RuntimeComponentV1Beta1GVK is the GroupVersionKind of the RuntimeComponent handled by operator versions 0.7.1 and below
+var RuntimeComponentV1Beta1GVK = schema.GroupVersionKind{Group: "app.stacks", Version: "v1beta1", Kind: "RuntimeComponent"}
+
+// Fields that were renamed or moved in v1beta2
+var v1beta1MovedFields = []struct {
+	from []string
+	to   []string
+}{
+	{[]string{"livenessProbe"}, []string{"probes", "liveness"}},
+	{[]string{"readinessProbe"}, []string{"probes", "readiness"}},
+	{[]string{"resourceConstraints"}, []string{"resources"}},
+	{[]string{"storage"}, []string{"statefulSet", "storage"}},
+	{[]string{"version"}, []string{"applicationVersion"}},
+	{[]string{"architecture"}, []string{"affinity", "architecture"}},
+}
+
+// Fields that were removed in v1beta2 and have no equivalent
+var v1beta1RemovedFields = [][]string{
+	{"bindings"},
+	{"createAppDefinition"},
+	{"route", "certificate"},
+	{"service", "certificate"},
+	{"service", "consumes"},
+	{"service", "provides"},
+}
+
+// ConvertV1Beta1RuntimeComponent converts an app.stacks/v1beta1 RuntimeComponent into an rc.app.stacks/v1beta2 RuntimeComponent.
+// It returns the paths of the fields that were set on the v1beta1 instance but have no equivalent in v1beta2.
+func ConvertV1Beta1RuntimeComponent(src *unstructured.Unstructured) (*appstacksv1beta2.RuntimeComponent, []string, error) {
+	spec, _, err := unstructured.NestedMap(src.Object, "spec")
+	if err != nil {
+		return nil, nil, err
+	}
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+
+	for _, f := range v1beta1MovedFields {
+		value, found, err := unstructured.NestedFieldCopy(spec, f.from...)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			continue
+		}
+		unstructured.RemoveNestedField(spec, f.from...)
+		if err := unstructured.SetNestedField(spec, value, f.to...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	dropped := []string{}
+	for _, f := range v1beta1RemovedFields {
+		if _, found, _ := unstructured.NestedFieldNoCopy(spec, f...); found {
+			dropped = append(dropped, ".spec."+strings.Join(f, "."))
+			unstructured.RemoveNestedField(spec, f...)
+		}
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	dst := &appstacksv1beta2.RuntimeComponent{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appstacksv1beta2.GroupVersion.String(),
+			Kind:       "RuntimeComponent",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        src.GetName(),
+			Namespace:   src.GetNamespace(),
+			Labels:      src.GetLabels(),
+			Annotations: map[string]string{},
+		},
+	}
+	if err := json.Unmarshal(data, &dst.Spec); err != nil {
+		return nil, nil, err
+	}
+
+	// Fields that are unknown to v1beta2 but not listed above are ignored by the decoding, so report them as well
+	converted, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&dst.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	dropped = append(dropped, unconvertedFields(".spec", spec, converted)...)
+
+	for k, v := range src.GetAnnotations() {
+		if k == "kubectl.kubernetes.io/last-applied-configuration" || k == MigratedToAnnotation {
+			continue
+		}
+		dst.Annotations[k] = v
+	}
+	dst.Annotations[MigratedFromAnnotation] = RuntimeComponentV1Beta1GVK.GroupVersion().String()
+
+	return dst, dropped, nil
+}
+
+// unconvertedFields returns the paths of the fields that are set in src but are missing from the converted object
+func unconvertedFields(path string, src interface{}, converted interface{}) []string {
+	if isEmptyValue(src) {
+		return nil
+	}
+	switch s := src.(type) {
+	case map[string]interface{}:
+		c, ok := converted.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		keys := make([]string, 0, len(s))
+		for k := range s {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fields := []string{}
+		for _, k := range keys {
+			value, found := c[k]
+			if !found {
+				if !isEmptyValue(s[k]) {
+					fields = append(fields, path+"."+k)
+				}
+				continue
+			}
+			fields = append(fields, unconvertedFields(path+"."+k, s[k], value)...)
+		}
+		return fields
+	case []interface{}:
+		c, ok := converted.([]interface{})
+		if !ok || len(c) != len(s) {
+			return []string{path}
+		}
+		fields := []string{}
+		for i := range s {
+			fields = append(fields, unconvertedFields(fmt.Sprintf("%s[%d]", path, i), s[i], c[i])...)
+		}
+		return fields
+	}
+	return nil
+}
+
+// isEmptyValue returns whether a field has the zero value of its type, which is omitted when the field is converted
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
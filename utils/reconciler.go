@@ -5,15 +5,18 @@ import (
 	"errors"
 	"fmt"
 	networkingv1 "k8s.io/api/networking/v1"
+	"reflect"
 	"time"
 
 	"github.com/application-stacks/runtime-component-operator/common"
 	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,17 +33,21 @@ import (
 
 const (
 	ReconcileInterval = 15
+
+	// FieldManager is the field manager used for server-side apply requests
+	FieldManager = "runtime-component-operator"
 )
 
 // ReconcilerBase base reconciler with some common behaviour
 type ReconcilerBase struct {
-	apiReader  client.Reader
-	client     client.Client
-	scheme     *runtime.Scheme
-	recorder   record.EventRecorder
-	restConfig *rest.Config
-	discovery  discovery.DiscoveryInterface
-	controller controller.Controller
+	apiReader      client.Reader
+	client         client.Client
+	scheme         *runtime.Scheme
+	recorder       record.EventRecorder
+	restConfig     *rest.Config
+	discovery      discovery.DiscoveryInterface
+	discoveryCache *DiscoveryCache
+	controller     controller.Controller
 }
 
 //NewReconcilerBase creates a new ReconcilerBase
@@ -100,6 +107,26 @@ func (r *ReconcilerBase) GetDiscoveryClient() (discovery.DiscoveryInterface, err
 // SetDiscoveryClient ...
 func (r *ReconcilerBase) SetDiscoveryClient(discovery discovery.DiscoveryInterface) {
 	r.discovery = discovery
+	r.discoveryCache = nil
+}
+
+// GetDiscoveryCache returns the cache of the discovered APIs, which is created for the discovery client when it is not set
+func (r *ReconcilerBase) GetDiscoveryCache() (*DiscoveryCache, error) {
+	if r.discoveryCache == nil {
+		cli, err := r.GetDiscoveryClient()
+		if err != nil {
+			return nil, err
+		}
+		r.discoveryCache = NewDiscoveryCache(cli, DefaultDiscoveryTTL)
+	}
+
+	return r.discoveryCache, nil
+}
+
+// SetDiscoveryCache sets the cache of the discovered APIs, which is shared by the reconcilers of the operator
+func (r *ReconcilerBase) SetDiscoveryCache(cache *DiscoveryCache) {
+	r.discovery = cache.client
+	r.discoveryCache = cache
 }
 
 var log = logf.Log.WithName("utils")
@@ -107,6 +134,10 @@ var log = logf.Log.WithName("utils")
 // CreateOrUpdate ...
 func (r *ReconcilerBase) CreateOrUpdate(obj client.Object, owner metav1.Object, reconcile func() error) error {
 
+	if common.GetConfig().ServerSideApply {
+		return r.apply(obj, owner, reconcile)
+	}
+
 	if owner != nil {
 		controllerutil.SetControllerReference(owner, obj, r.scheme)
 	}
@@ -120,11 +151,100 @@ func (r *ReconcilerBase) CreateOrUpdate(obj client.Object, owner metav1.Object,
 	gvk, err = apiutil.GVKForObject(obj, r.scheme)
 	if err == nil {
 		log.Info("Reconciled", "Kind", gvk.Kind, "Namespace", obj.GetNamespace(), "Name", obj.GetName(), "Status", result)
+		recordGeneratedResource(owner, obj, gvk)
 	}
 
 	return err
 }
 
+// apply builds the desired state of obj from scratch using the reconcile function and sends it as a
+// server-side apply request, so that only the fields set by the operator are owned by the operator.
+// The zero values that the reconcile function sets over the current values of an existing object are
+// found by running it on the current object first. obj is updated with the object returned by the API server.
+func (r *ReconcilerBase) apply(obj client.Object, owner metav1.Object, reconcile func() error) error {
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		return err
+	}
+
+	// Resources created or updated before server-side apply was enabled are owned by the operator's
+	// update requests. Take ownership of those fields on the first apply, and report conflicts afterwards.
+	force := false
+	var existing client.Object
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		existing = u
+	} else {
+		o, err := r.scheme.New(gvk)
+		if err != nil {
+			return err
+		}
+		existing = o.(client.Object)
+	}
+	name, namespace := obj.GetName(), obj.GetNamespace()
+	var zeroFields []zeroField
+	err = r.GetClient().Get(context.TODO(), client.ObjectKeyFromObject(obj), existing)
+	if err == nil {
+		force = !isAppliedBy(existing, FieldManager)
+		if _, ok := obj.(*unstructured.Unstructured); !ok {
+			if zeroFields, err = findZeroWrites(obj, existing, reconcile); err != nil {
+				return err
+			}
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	v := reflect.ValueOf(obj).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	if err := reconcile(); err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	if owner != nil {
+		controllerutil.SetControllerReference(owner, obj, r.scheme)
+	}
+
+	body, err := applyConfiguration(obj, gvk, zeroFields)
+	if err != nil {
+		return err
+	}
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
+	}
+	err = r.GetClient().Patch(context.TODO(), body, client.Apply, opts...)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf("failed to apply %s %s/%s, the fields are managed by another field manager: %w", gvk.Kind, namespace, name, err)
+		}
+		return err
+	}
+	if _, ok := obj.(*unstructured.Unstructured); !ok {
+		v.Set(reflect.Zero(v.Type()))
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(body.Object, obj); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Reconciled", "Kind", gvk.Kind, "Namespace", namespace, "Name", name, "Status", "applied")
+	recordGeneratedResource(owner, obj, gvk)
+	return nil
+}
+
+func isAppliedBy(obj client.Object, manager string) bool {
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Manager == manager && mf.Operation == metav1.ManagedFieldsOperationApply {
+			return true
+		}
+	}
+	return false
+}
+
 // DeleteResource deletes kubernetes resource
 func (r *ReconcilerBase) DeleteResource(obj client.Object) error {
 	err := r.client.Delete(context.TODO(), obj)
@@ -133,6 +253,9 @@ func (r *ReconcilerBase) DeleteResource(obj client.Object) error {
 			log.Error(err, "Unable to delete object ", "object", obj)
 			return err
 		}
+		if gvk, err := apiutil.GVKForObject(obj, r.scheme); err == nil {
+			componentMetrics.forgetResource(obj, gvk)
+		}
 		return nil
 	}
 
@@ -147,6 +270,7 @@ func (r *ReconcilerBase) DeleteResource(obj client.Object) error {
 	gvk, err = apiutil.GVKForObject(obj, r.scheme)
 	if err == nil {
 		log.Info("Reconciled", "Kind", gvk.Kind, "Name", metaObj.GetName(), "Status", "deleted")
+		componentMetrics.forgetResource(obj, gvk)
 	}
 	return nil
 }
@@ -179,11 +303,12 @@ func (r *ReconcilerBase) ManageError(issue error, conditionType common.StatusCon
 	logger := log.WithValues("ba.Namespace", obj.GetNamespace(), "ba.Name", obj.GetName())
 	logger.Error(issue, "ManageError", "Condition", conditionType, "ba", ba)
 	r.GetRecorder().Event(obj, "Warning", "ProcessingError", issue.Error())
+	recordReconcileError(ErrorReason(issue))
 
 	oldCondition := s.GetCondition(conditionType)
 
 	newCondition := s.NewCondition(conditionType)
-	newCondition.SetReason(string(apierrors.ReasonForError(issue)))
+	newCondition.SetReason(ErrorReason(issue))
 	newCondition.SetMessage(issue.Error())
 	newCondition.SetStatus(corev1.ConditionFalse)
 	s.SetCondition(newCondition)
@@ -253,12 +378,13 @@ func (r *ReconcilerBase) ManageSuccess(conditionType common.StatusConditionType,
 	return reconcile.Result{RequeueAfter: retryInterval}, nil
 }
 
-// IsGroupVersionSupported ...
+// IsGroupVersionSupported returns whether the kind of the group version is installed on the cluster. The discovered
+// group versions are cached, see DiscoveryCache.
 func (r *ReconcilerBase) IsGroupVersionSupported(groupVersion string, kind string) (bool, error) {
-	cli, err := r.GetDiscoveryClient()
+	cli, err := r.GetDiscoveryCache()
 	if err != nil {
 		log.Error(err, "Failed to return a discovery client for the current reconciler")
-		return false, err
+		return false, &DiscoveryError{GroupVersion: groupVersion, Err: err}
 	}
 
 	res, err := cli.ServerResourcesForGroupVersion(groupVersion)
@@ -267,7 +393,7 @@ func (r *ReconcilerBase) IsGroupVersionSupported(groupVersion string, kind strin
 			return false, nil
 		}
 
-		return false, err
+		return false, &DiscoveryError{GroupVersion: groupVersion, Err: err}
 	}
 
 	for _, v := range res.APIResources {
@@ -279,6 +405,23 @@ func (r *ReconcilerBase) IsGroupVersionSupported(groupVersion string, kind strin
 	return false, nil
 }
 
+// IsAllowed returns whether the operator is allowed to perform the verb on the resources of the group in all namespaces
+func (r *ReconcilerBase) IsAllowed(verb string, groupResource schema.GroupResource) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     verb,
+				Group:    groupResource.Group,
+				Resource: groupResource.Resource,
+			},
+		},
+	}
+	if err := r.GetClient().Create(context.TODO(), review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
 // UpdateStatus updates the fields corresponding to the status subresource for the object
 func (r *ReconcilerBase) UpdateStatus(obj client.Object) error {
 	return r.GetClient().Status().Update(context.Background(), obj)
@@ -310,12 +453,8 @@ func (r *ReconcilerBase) GetRouteTLSValues(ba common.BaseComponent) (key string,
 			destCa = string(caCrt)
 		}
 	}
-	if ba.GetRoute() != nil && ba.GetRoute().GetCertificateSecretRef() != nil {
+	if secretName := GetRouteTLSSecretName(ba); secretName != "" {
 		tlsSecret := &corev1.Secret{}
-		secretName := mObj.GetName() + "-route-tls"
-		if ba.GetRoute().GetCertificateSecretRef() != nil {
-			secretName = *ba.GetRoute().GetCertificateSecretRef()
-		}
 		err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: mObj.GetNamespace()}, tlsSecret)
 		if err != nil {
 			r.ManageError(err, common.StatusConditionTypeReconciled, ba)
@@ -344,130 +483,90 @@ func (r *ReconcilerBase) GetRouteTLSValues(ba common.BaseComponent) (key string,
 func (r *ReconcilerBase) GenerateSvcCertSecret(ba common.BaseComponent, prefix string, CACommonName string, operatorName string) (bool, error) {
 
 	delete(ba.GetStatus().GetReferences(), common.StatusReferenceCertSecretName)
-	cleanup := func() {
-		if ok, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate"); err != nil {
-			return
-		} else if ok {
-			obj := ba.(metav1.Object)
-			svcCert := &certmanagerv1.Certificate{}
-			svcCert.Name = obj.GetName() + "-svc-tls"
-			svcCert.Namespace = obj.GetNamespace()
-			r.client.Delete(context.Background(), svcCert)
-		}
+	cleanup := func() error {
+		return r.DeleteSvcCertSecret(ba, prefix)
 	}
 
-	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
-		cleanup()
-		return false, nil
-	}
-	if ba.GetService() != nil && ba.GetService().GetCertificateSecretRef() != nil {
-		cleanup()
-		return false, nil
-	}
-	if ba.GetManageTLS() != nil && !*ba.GetManageTLS() {
-		cleanup()
-		return false, nil
-	}
-	if ba.GetService() != nil && ba.GetService().GetAnnotations() != nil {
-		if _, ok := ba.GetService().GetAnnotations()["service.beta.openshift.io/serving-cert-secret-name"]; ok {
-			cleanup()
-			return false, nil
-		}
-		if _, ok := ba.GetService().GetAnnotations()["service.alpha.openshift.io/serving-cert-secret-name"]; ok {
-			cleanup()
-			return false, nil
-		}
+	if !RequestsServiceCertificate(ba) {
+		return false, cleanup()
 	}
 	if ok, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate"); err != nil {
 		return false, err
 	} else if ok {
 		bao := ba.(metav1.Object)
+		svcCertSecretName := bao.GetName() + "-svc-tls-cm"
 
-		issuer := &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
-			Name:      prefix + "-self-signed",
-			Namespace: bao.GetNamespace(),
-		}}
-		err = r.CreateOrUpdate(issuer, nil, func() error {
-			issuer.Spec.SelfSigned = &certmanagerv1.SelfSignedIssuer{}
-			issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
-			return nil
-		})
-		if err != nil {
-			return true, err
-		}
-		caCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{
-			Name:      prefix + "-ca-cert",
-			Namespace: bao.GetNamespace(),
-		}}
-		err = r.CreateOrUpdate(caCert, nil, func() error {
-			caCert.Labels = MergeMaps(caCert.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
-			caCert.Spec.CommonName = CACommonName
-			caCert.Spec.IsCA = true
-			caCert.Spec.SecretName = prefix + "-ca-tls"
-			caCert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
-				Name: prefix + "-self-signed",
+		issuerRef, managedIssuer := GetServiceCertificateIssuer(ba, prefix)
+		if managedIssuer {
+			issuer := &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
+				Name:      prefix + "-self-signed",
+				Namespace: bao.GetNamespace(),
+			}}
+			err = r.CreateOrUpdate(issuer, nil, func() error {
+				issuer.Spec.SelfSigned = &certmanagerv1.SelfSignedIssuer{}
+				issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+				return nil
+			})
+			if err != nil {
+				return true, err
 			}
+			caCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{
+				Name:      prefix + "-ca-cert",
+				Namespace: bao.GetNamespace(),
+			}}
+			err = r.CreateOrUpdate(caCert, nil, func() error {
+				caCert.Labels = MergeMaps(caCert.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+				caCert.Spec.CommonName = CACommonName
+				caCert.Spec.IsCA = true
+				caCert.Spec.SecretName = prefix + "-ca-tls"
+				caCert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
+					Name: prefix + "-self-signed",
+				}
 
-			duration, err := time.ParseDuration(common.Config[common.OpConfigCMCADuration])
+				caCert.Spec.Duration = &metav1.Duration{Duration: common.GetConfig().CACertDuration}
+				return nil
+			})
 			if err != nil {
-				return err
+				return true, err
+			}
+			issuer = &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
+				Name:      prefix + "-ca-issuer",
+				Namespace: bao.GetNamespace(),
+			}}
+			err = r.CreateOrUpdate(issuer, nil, func() error {
+				issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+				issuer.Spec.CA = &certmanagerv1.CAIssuer{}
+				issuer.Spec.CA.SecretName = prefix + "-ca-tls"
+				return nil
+			})
+			if err != nil {
+				return true, err
 			}
-			caCert.Spec.Duration = &metav1.Duration{Duration: duration}
-			return nil
-		})
-		if err != nil {
-			return true, err
-		}
-		issuer = &certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{
-			Name:      prefix + "-ca-issuer",
-			Namespace: bao.GetNamespace(),
-		}}
-		err = r.CreateOrUpdate(issuer, nil, func() error {
-			issuer.Labels = MergeMaps(issuer.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
-			issuer.Spec.CA = &certmanagerv1.CAIssuer{}
-			issuer.Spec.CA.SecretName = prefix + "-ca-tls"
-			return nil
-		})
-		if err != nil {
-			return true, err
-		}
 
-		for i := range issuer.Status.Conditions {
-			if issuer.Status.Conditions[i].Type == certmanagerv1.IssuerConditionReady && issuer.Status.Conditions[i].Status == certmanagermetav1.ConditionFalse {
-				return true, errors.New("Certificate is not ready")
+			for i := range issuer.Status.Conditions {
+				if issuer.Status.Conditions[i].Type == certmanagerv1.IssuerConditionReady && issuer.Status.Conditions[i].Status == certmanagermetav1.ConditionFalse {
+					return true, fmt.Errorf("Certificate is %w", ErrNotReady)
+				}
 			}
 		}
 
-		svcCertSecretName := bao.GetName() + "-svc-tls-cm"
-
 		svcCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{
 			Name:      svcCertSecretName,
 			Namespace: bao.GetNamespace(),
 		}}
 
 		err = r.CreateOrUpdate(svcCert, bao, func() error {
-			svcCert.Labels = ba.GetLabels()
-
-			svcCert.Spec.CommonName = bao.GetName() + "." + bao.GetNamespace() + ".svc"
-			svcCert.Spec.DNSNames = make([]string, 2)
-			svcCert.Spec.DNSNames[0] = bao.GetName() + "." + bao.GetNamespace() + ".svc"
-			svcCert.Spec.DNSNames[1] = bao.GetName() + "." + bao.GetNamespace() + ".svc.cluster.local"
-			svcCert.Spec.IsCA = false
-			svcCert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
-				Name: prefix + "-ca-issuer",
-			}
-			svcCert.Spec.SecretName = svcCertSecretName
-			duration, err := time.ParseDuration(common.Config[common.OpConfigCMCertDuration])
-			if err != nil {
-				return err
-			}
-			svcCert.Spec.Duration = &metav1.Duration{Duration: duration}
-
-			return nil
+			return CustomizeServiceCertificate(svcCert, ba, issuerRef)
 		})
 		if err != nil {
 			return true, err
 		}
+		if !managedIssuer {
+			// The CA issuer managed by the operator may not be used anymore
+			if err := r.deleteUnusedCAIssuer(bao.GetNamespace(), prefix, svcCertSecretName); err != nil {
+				return true, err
+			}
+		}
 		ba.GetStatus().SetReference(common.StatusReferenceCertSecretName, svcCertSecretName)
 	} else {
 		return false, nil
@@ -475,9 +574,211 @@ func (r *ReconcilerBase) GenerateSvcCertSecret(ba common.BaseComponent, prefix s
 	return true, nil
 }
 
+// DeleteSvcCertSecret deletes the cert-manager Certificate created for the component's service and its secret.
+// The issuers and the CA certificate shared by the components of the namespace are deleted when no other
+// certificate is issued by them.
+func (r *ReconcilerBase) DeleteSvcCertSecret(ba common.BaseComponent, prefix string) error {
+	if ok, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate"); err != nil || !ok {
+		return err
+	}
+
+	bao := ba.(metav1.Object)
+	svcCertSecretName := bao.GetName() + "-svc-tls-cm"
+	svcCert := &certmanagerv1.Certificate{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: svcCertSecretName, Namespace: bao.GetNamespace()}, svcCert)
+	if err == nil {
+		resources := []client.Object{
+			svcCert,
+			// The secret is not owned by the certificate unless cert-manager is configured to do so
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: svcCertSecretName, Namespace: bao.GetNamespace()}},
+		}
+		if err := r.DeleteResources(resources); err != nil {
+			return err
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := r.deleteClientCertSecret(ba); err != nil {
+		return err
+	}
+	return r.deleteUnusedCAIssuer(bao.GetNamespace(), prefix, svcCertSecretName)
+}
+
+// ReconcileMTLS requests the mutual TLS client certificate of the component from the CA issuer managed by the operator and
+// maintains the trust bundle of the namespace, which holds the CA certificate of that issuer. It deletes the client certificate
+// when mutual TLS is disabled or the service certificate is not issued by cert-manager.
+func (r *ReconcilerBase) ReconcileMTLS(ba common.BaseComponent, prefix string, operatorName string, useCertmanager bool) error {
+	delete(ba.GetStatus().GetReferences(), common.StatusReferenceClientCertSecretName)
+	delete(ba.GetStatus().GetReferences(), common.StatusReferenceTrustBundleName)
+	if !IsMTLSEnabled(ba) || !useCertmanager {
+		if ok, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate"); err != nil || !ok {
+			return err
+		}
+		return r.deleteClientCertSecret(ba)
+	}
+
+	issuerRef, managedIssuer := GetServiceCertificateIssuer(ba, prefix)
+	if !managedIssuer {
+		return fmt.Errorf("failed to enable mutual TLS as the service certificate is issued by %s %s instead of the CA issuer managed by the operator", issuerRef.Kind, issuerRef.Name)
+	}
+
+	bao := ba.(metav1.Object)
+	clientCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{
+		Name:      GetClientCertificateName(ba),
+		Namespace: bao.GetNamespace(),
+	}}
+	err := r.CreateOrUpdate(clientCert, bao, func() error {
+		return CustomizeClientCertificate(clientCert, ba, issuerRef)
+	})
+	if err != nil {
+		return err
+	}
+
+	caSecret := &corev1.Secret{}
+	err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: GetCASecretName(prefix), Namespace: bao.GetNamespace()}, caSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get the CA certificate of the trust bundle: %w", err)
+	}
+	caCrt, ok := caSecret.Data["ca.crt"]
+	if !ok {
+		caCrt = caSecret.Data["tls.crt"]
+	}
+	bundle := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      GetTrustBundleName(prefix),
+		Namespace: bao.GetNamespace(),
+	}}
+	err = r.CreateOrUpdate(bundle, nil, func() error {
+		bundle.Labels = MergeMaps(bundle.Labels, map[string]string{"app.kubernetes.io/managed-by": operatorName})
+		bundle.Data = map[string]string{"ca.crt": string(caCrt)}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ba.GetStatus().SetReference(common.StatusReferenceClientCertSecretName, clientCert.Spec.SecretName)
+	ba.GetStatus().SetReference(common.StatusReferenceTrustBundleName, bundle.Name)
+	return nil
+}
+
+// deleteClientCertSecret deletes the mutual TLS client certificate of the component and its secret
+func (r *ReconcilerBase) deleteClientCertSecret(ba common.BaseComponent) error {
+	bao := ba.(metav1.Object)
+	clientCert := &certmanagerv1.Certificate{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: GetClientCertificateName(ba), Namespace: bao.GetNamespace()}, clientCert)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return r.DeleteResources([]client.Object{
+		clientCert,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: clientCert.Name, Namespace: bao.GetNamespace()}},
+	})
+}
+
+// deleteUnusedCAIssuer deletes the namespace-shared issuers, CA certificate and CA secret when no certificate
+// other than the excluded one references the CA issuer
+func (r *ReconcilerBase) deleteUnusedCAIssuer(namespace string, prefix string, excludedCert string) error {
+	issuer := &certmanagerv1.Issuer{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: prefix + "-ca-issuer", Namespace: namespace}, issuer)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	certs := &certmanagerv1.CertificateList{}
+	if err := r.GetClient().List(context.TODO(), certs, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	for _, cert := range certs.Items {
+		if cert.Name == excludedCert || cert.DeletionTimestamp != nil {
+			continue
+		}
+		if cert.Spec.IssuerRef.Name == prefix+"-ca-issuer" && (cert.Spec.IssuerRef.Kind == "" || cert.Spec.IssuerRef.Kind == certmanagerv1.IssuerKind) {
+			return nil
+		}
+	}
+
+	return r.DeleteResources([]client.Object{
+		issuer,
+		&certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: prefix + "-ca-cert", Namespace: namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: GetCASecretName(prefix), Namespace: namespace}},
+		&certmanagerv1.Issuer{ObjectMeta: metav1.ObjectMeta{Name: prefix + "-self-signed", Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: GetTrustBundleName(prefix), Namespace: namespace}},
+	})
+}
+
+// ReconcileRouteCertificate requests the certificate of spec.route.certificate from cert-manager when the application
+// is exposed, and deletes it otherwise. The secret of the certificate is referenced in the status, and used by the
+// Route or the Ingress, once cert-manager reports the certificate as ready.
+func (r *ReconcilerBase) ReconcileRouteCertificate(ba common.BaseComponent, expose bool) error {
+	delete(ba.GetStatus().GetReferences(), common.StatusReferenceRouteCertSecretName)
+	bao := ba.(metav1.Object)
+	certName := GetRouteCertificateName(ba)
+
+	ok, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	if err != nil {
+		return err
+	}
+	if !expose || ba.GetRoute() == nil || ba.GetRoute().GetCertificate() == nil {
+		if !ok {
+			return nil
+		}
+		cert := &certmanagerv1.Certificate{}
+		err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: certName, Namespace: bao.GetNamespace()}, cert)
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return r.DeleteResources([]client.Object{
+			cert,
+			// The secret is not owned by the certificate unless cert-manager is configured to do so
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: certName, Namespace: bao.GetNamespace()}},
+		})
+	}
+	if !ok {
+		return fmt.Errorf("failed to request the certificate of the route as %s Certificate is %w", certmanagerv1.SchemeGroupVersion.String(), ErrNotSupported)
+	}
+
+	cert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: certName, Namespace: bao.GetNamespace()}}
+	err = r.CreateOrUpdate(cert, bao, func() error {
+		CustomizeRouteCertificate(cert, ba)
+		if len(cert.Spec.DNSNames) == 0 {
+			return errors.New("failed to request the certificate of the route as the route has no host. Set spec.route.host or the defaultHostName operator configuration")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if isCertificateReady(cert) {
+		ba.GetStatus().SetReference(common.StatusReferenceRouteCertSecretName, cert.Spec.SecretName)
+	}
+	return nil
+}
+
+// isCertificateReady returns whether cert-manager issued the current spec of the certificate
+func isCertificateReady(cert *certmanagerv1.Certificate) bool {
+	for _, c := range cert.Status.Conditions {
+		if c.Type == certmanagerv1.CertificateConditionReady {
+			return c.Status == certmanagermetav1.ConditionTrue && (c.ObservedGeneration == 0 || c.ObservedGeneration == cert.Generation)
+		}
+	}
+	return false
+}
+
 func (r *ReconcilerBase) GetIngressInfo(ba common.BaseComponent) (host string, path string, protocol string) {
 	mObj := ba.(metav1.Object)
 	protocol = "http"
+	// Failures to check the Gateway API are reported by the reconcile of the routes
+	if ok, err := r.UseGatewayAPI(ba); err != nil {
+		return host, path, protocol
+	} else if ok {
+		return r.getGatewayRouteInfo(ba)
+	}
 	if ok, err := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route"); err != nil {
 		r.ManageError(err, common.StatusConditionTypeReconciled, ba)
 	} else if ok {
@@ -509,3 +810,78 @@ func (r *ReconcilerBase) GetIngressInfo(ba common.BaseComponent) (host string, p
 	}
 	return host, path, protocol
 }
+
+// GetIngressEndpoints returns the URLs of all the hosts and paths that the Route, Ingress or Gateway API route of the application exposes
+func (r *ReconcilerBase) GetIngressEndpoints(ba common.BaseComponent) []string {
+	mObj := ba.(metav1.Object)
+	endpoints := []string{}
+	addEndpoint := func(protocol string, host string, path string) {
+		// If route/ingress host is empty, host is set to wildcard
+		if host == "" {
+			host = "*"
+		}
+		endpoints = append(endpoints, fmt.Sprintf("%s://%s%s", protocol, host, path))
+	}
+
+	// Failures to check the Gateway API are reported by the reconcile of the routes
+	if ok, err := r.UseGatewayAPI(ba); err != nil {
+		return endpoints
+	} else if ok {
+		host, path, protocol := r.getGatewayRouteInfo(ba)
+		addEndpoint(protocol, host, path)
+		if !UseTLSRoute(ba) {
+			for _, p := range GetRoutePaths(ba)[1:] {
+				addEndpoint(protocol, p.Host, p.Path)
+			}
+		}
+		return endpoints
+	}
+
+	if ok, err := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route"); err != nil {
+		r.ManageError(err, common.StatusConditionTypeReconciled, ba)
+	} else if ok {
+		names := []string{mObj.GetName()}
+		for i := range GetRoutePaths(ba)[1:] {
+			names = append(names, GetAdditionalRouteName(ba, i))
+		}
+		for _, name := range names {
+			route := &routev1.Route{}
+			if err := r.GetClient().Get(context.Background(), types.NamespacedName{Name: name, Namespace: mObj.GetNamespace()}, route); err != nil {
+				continue
+			}
+			protocol := "http"
+			if route.Spec.TLS != nil {
+				protocol = "https"
+			}
+			addEndpoint(protocol, route.Spec.Host, route.Spec.Path)
+		}
+		return endpoints
+	}
+
+	if ok, err := r.IsGroupVersionSupported(networkingv1.SchemeGroupVersion.String(), "Ingress"); err != nil {
+		r.ManageError(err, common.StatusConditionTypeReconciled, ba)
+	} else if ok {
+		ingress := &networkingv1.Ingress{}
+		if err := r.GetClient().Get(context.Background(), types.NamespacedName{Name: mObj.GetName(), Namespace: mObj.GetNamespace()}, ingress); err != nil {
+			return endpoints
+		}
+		tlsHosts := []string{}
+		for _, tls := range ingress.Spec.TLS {
+			tlsHosts = append(tlsHosts, tls.Hosts...)
+		}
+		for _, rule := range ingress.Spec.Rules {
+			protocol := "http"
+			if rule.Host != "" && ContainsString(tlsHosts, rule.Host) {
+				protocol = "https"
+			}
+			if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+				addEndpoint(protocol, rule.Host, "")
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				addEndpoint(protocol, rule.Host, path.Path)
+			}
+		}
+	}
+	return endpoints
+}
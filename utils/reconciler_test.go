@@ -2,18 +2,35 @@ package utils
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/application-stacks/runtime-component-operator/common"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	dto "github.com/prometheus/client_model/go"
 
 	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	fakediscovery "k8s.io/client-go/discovery/fake"
@@ -21,6 +38,9 @@ import (
 	"k8s.io/client-go/rest"
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingv1alpha1 "knative.dev/serving/pkg/apis/serving/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -84,6 +104,86 @@ func TestCreateOrUpdate(t *testing.T) {
 	verifyTests(testCOU, t)
 }
 
+func TestCreateOrUpdateApply(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	configured, _ := common.ParseOpConfig(map[string]string{common.OpConfigServerSideApply: "true"})
+	common.SetConfig(configured)
+	defer common.SetConfig(nil)
+
+	runtimecomponent := createRuntimeComponent(name, namespace, spec)
+	runtimecomponent.Spec.Service = service
+	runtimecomponent.Spec.Route = &appstacksv1beta2.RuntimeComponentRoute{Gateway: &appstacksv1beta2.RuntimeComponentGateway{Name: "my-gateway"}}
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtimecomponent)
+	// The Deployment was created by an update request before server-side apply was enabled, and paused and
+	// configured by other managers
+	var zeroReplicas, threeReplicas int32 = 0, 3
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{Replicas: &threeReplicas, Paused: true, MinReadySeconds: 5}}
+	cl := &applyRecorder{Client: fakeclient.NewFakeClientWithScheme(s, runtimecomponent, existing)}
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	errTyped := r.CreateOrUpdate(deploy, runtimecomponent, func() error {
+		deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}}
+		deploy.Spec.Template.Labels = map[string]string{"app": name}
+		deploy.Spec.Template.Spec.Containers = []corev1.Container{{Name: "app", Image: appImage}}
+		deploy.Spec.Template.Spec.Volumes = []corev1.Volume{{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+		deploy.Spec.Replicas = &zeroReplicas
+		deploy.Spec.Paused = false
+		return nil
+	})
+	typed := cl.patches[0]
+	_, hasStatus := typed["status"]
+	replicas, _, _ := unstructured.NestedFieldNoCopy(typed, "spec", "replicas")
+	paused, _, _ := unstructured.NestedFieldNoCopy(typed, "spec", "paused")
+	_, hasMinReadySeconds, _ := unstructured.NestedFieldNoCopy(typed, "spec", "minReadySeconds")
+	_, hasStrategy, _ := unstructured.NestedFieldNoCopy(typed, "spec", "strategy")
+	_, hasTimestamp, _ := unstructured.NestedFieldNoCopy(typed, "metadata", "creationTimestamp")
+	containers, _, _ := unstructured.NestedSlice(typed, "spec", "template", "spec", "containers")
+	_, hasResources := containers[0].(map[string]interface{})["resources"]
+	volumes, _, _ := unstructured.NestedSlice(typed, "spec", "template", "spec", "volumes")
+	owners, _, _ := unstructured.NestedSlice(typed, "metadata", "ownerReferences")
+	image := deploy.Spec.Template.Spec.Containers[0].Image
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGVK)
+	route.SetName(name)
+	route.SetNamespace(namespace)
+	errUnstructured := r.CreateOrUpdate(route, runtimecomponent, func() error {
+		CustomizeHTTPRoute(route, runtimecomponent)
+		return nil
+	})
+	parentRefs, _, _ := unstructured.NestedSlice(cl.patches[1], "spec", "parentRefs")
+
+	cl.err = apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, name, fmt.Errorf("conflict with \"kubectl\""))
+	errConflict := r.CreateOrUpdate(deploy, runtimecomponent, func() error { return nil })
+
+	testCOUA := []Test{
+		{"Typed apply error", nil, errTyped},
+		{"Typed apply forces ownership of existing resource", true, cl.force[0]},
+		{"Typed apply kind", "Deployment", typed["kind"]},
+		{"Typed apply without status", false, hasStatus},
+		{"Typed apply zero replicas", float64(0), replicas},
+		{"Typed apply unpauses", false, paused},
+		{"Typed apply without fields of other managers", false, hasMinReadySeconds},
+		{"Typed apply without empty strategy", false, hasStrategy},
+		{"Typed apply without creation timestamp", false, hasTimestamp},
+		{"Typed apply without empty container resources", false, hasResources},
+		{"Typed apply keeps empty volume source", map[string]interface{}{}, volumes[0].(map[string]interface{})["emptyDir"]},
+		{"Typed apply owner", name, owners[0].(map[string]interface{})["name"]},
+		{"Typed apply result", appImage, image},
+		{"Unstructured apply error", nil, errUnstructured},
+		{"Unstructured apply does not force new resource", false, cl.force[1]},
+		{"Unstructured apply kind", HTTPRouteGVK.Kind, cl.patches[1]["kind"]},
+		{"Unstructured apply parent", 1, len(parentRefs)},
+		{"Conflict reported", true, apierrors.IsConflict(errConflict)},
+		{"Conflict message", true, errConflict != nil && strings.Contains(errConflict.Error(), "managed by another field manager")},
+	}
+	verifyTests(testCOUA, t)
+}
+
 func TestDeleteResources(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
@@ -240,6 +340,639 @@ func TestIsGroupVersionSupported(t *testing.T) {
 	}
 }
 
+// failingDiscovery is a discovery client that fails to discover the given group version
+type failingDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	groupVersion string
+}
+
+func (d *failingDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	if groupVersion == d.groupVersion {
+		return nil, fmt.Errorf("connection refused")
+	}
+	return d.FakeDiscovery.ServerResourcesForGroupVersion(groupVersion)
+}
+
+func TestCheckDiscovery(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	cl := fakeclient.NewFakeClient()
+	r := NewReconcilerBase(cl, cl, scheme.Scheme, &rest.Config{}, record.NewFakeRecorder(10))
+
+	// Optional APIs that are not installed are not discovery failures
+	r.SetDiscoveryClient(createFakeDiscoveryClient())
+	errMissing := r.CheckDiscovery()
+
+	r.SetDiscoveryClient(&failingDiscovery{
+		FakeDiscovery: createFakeDiscoveryClient().(*fakediscovery.FakeDiscovery),
+		groupVersion:  certmanagerv1.SchemeGroupVersion.String(),
+	})
+	errFailing := r.CheckDiscovery()
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	errCheck := r.DiscoveryCheck()(req)
+
+	testCD := []Test{
+		{"Missing optional APIs", nil, errMissing},
+		{"Failed discovery is reported", true, errFailing != nil && strings.Contains(errFailing.Error(), certmanagerv1.SchemeGroupVersion.String()+": connection refused")},
+		{"Failed discovery fails the readiness check", errFailing, errCheck},
+	}
+	verifyTests(testCD, t)
+}
+
+// countingDiscovery is a discovery client that counts the lookups of group versions
+type countingDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	lookups int
+}
+
+func (d *countingDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	d.lookups++
+	return d.FakeDiscovery.ServerResourcesForGroupVersion(groupVersion)
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	cl := fakeclient.NewFakeClient()
+	r := NewReconcilerBase(cl, cl, scheme.Scheme, &rest.Config{}, record.NewFakeRecorder(10))
+	dc := &countingDiscovery{FakeDiscovery: createFakeDiscoveryClient().(*fakediscovery.FakeDiscovery)}
+	r.SetDiscoveryCache(NewDiscoveryCache(dc, time.Hour))
+
+	// Supported and missing group versions are both cached
+	routeSupported, _ := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+	r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+	certSupported, _ := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	cachedLookups := dc.lookups
+
+	// The API is installed and its group is invalidated
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: certmanagerv1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "certificates", Namespaced: true, Kind: "Certificate"}},
+	})
+	certCached, _ := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	cache, _ := r.GetDiscoveryCache()
+	cache.Invalidate(certmanagerv1.SchemeGroupVersion.Group)
+	certInvalidated, _ := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+	invalidatedLookups := dc.lookups
+
+	// Expired group versions are looked up again
+	r.SetDiscoveryCache(NewDiscoveryCache(dc, 0))
+	r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+	r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+
+	// Discovery failures are not cached
+	failing := &failingDiscovery{FakeDiscovery: dc.FakeDiscovery, groupVersion: routev1.SchemeGroupVersion.String()}
+	r.SetDiscoveryCache(NewDiscoveryCache(failing, time.Hour))
+	_, errFailing := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+	failing.groupVersion = ""
+	routeRecovered, errRecovered := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+
+	testDC := []Test{
+		{"Supported group version", true, routeSupported},
+		{"Missing group version", false, certSupported},
+		{"Group versions are looked up once", 2, cachedLookups},
+		{"Missing group version is cached", false, certCached},
+		{"Invalidated group version is looked up again", true, certInvalidated},
+		{"Other groups stay cached", 3, invalidatedLookups},
+		{"Expired group versions are looked up again", 5, dc.lookups},
+		{"Discovery failure", true, errFailing != nil},
+		{"Discovery failure is not cached", nil, errRecovered},
+		{"Group version supported after discovery recovers", true, routeRecovered},
+	}
+	verifyTests(testDC, t)
+}
+
+func TestCheckKnativeStatus(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	expose := true
+	runtime := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{CreateKnativeService: &createKNS, Expose: &expose})
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+	servingv1.AddToScheme(s)
+
+	ksvc := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 2}}
+	ksvc.Status.ObservedGeneration = 2
+	ksvc.Status.LatestCreatedRevisionName = name + "-00002"
+	ksvc.Status.LatestReadyRevisionName = name + "-00001"
+	ksvc.Status.Conditions = duckv1.Conditions{
+		{Type: servingv1.ServiceConditionConfigurationsReady, Status: corev1.ConditionFalse, Reason: "RevisionFailed", Message: "Back-off pulling image"},
+		{Type: servingv1.ServiceConditionRoutesReady, Status: corev1.ConditionTrue},
+		{Type: servingv1.ServiceConditionReady, Status: corev1.ConditionFalse, Reason: "RevisionFailed"},
+	}
+	ksvc.Status.URL = &apis.URL{Scheme: "https", Host: "my-app.runtime.example.com"}
+	ksvc.Status.Traffic = []servingv1.TrafficTarget{{Tag: "preview", URL: &apis.URL{Scheme: "https", Host: "preview-my-app.runtime.example.com"}}}
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime, ksvc)
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+
+	// Copy the condition, as it is updated in place by later checks
+	resourcesReady := func() appstacksv1beta2.StatusCondition {
+		r.CheckResourcesStatus(runtime)
+		return *runtime.Status.GetCondition(common.StatusConditionTypeResourcesReady).(*appstacksv1beta2.StatusCondition)
+	}
+	failed := resourcesReady()
+
+	ksvc.Status.Conditions[0] = apis.Condition{Type: servingv1.ServiceConditionConfigurationsReady, Status: corev1.ConditionTrue}
+	ksvc.Status.Conditions[2] = apis.Condition{Type: servingv1.ServiceConditionReady, Status: corev1.ConditionTrue}
+	cl.Status().Update(context.TODO(), ksvc)
+	starting := resourcesReady()
+
+	ksvc.Status.LatestReadyRevisionName = ksvc.Status.LatestCreatedRevisionName
+	cl.Status().Update(context.TODO(), ksvc)
+	ready := resourcesReady()
+
+	r.ReportKnativeEndpointStatus(runtime)
+	endpoints := runtime.Status.Endpoints
+
+	testCKS := []Test{
+		{"Failed revision status", corev1.ConditionFalse, failed.Status},
+		{"Failed revision reason", "RevisionFailed", failed.Reason},
+		{"Failed revision message", "Knative service ConfigurationsReady condition is False: Back-off pulling image", failed.Message},
+		{"Latest revision not ready", "RevisionNotReady", starting.Reason},
+		{"Knative service ready", corev1.ConditionTrue, ready.Status},
+		{"Knative endpoints", []appstacksv1beta2.StatusEndpoint{
+			{Name: KnativeEndpointName, Scope: appstacksv1beta2.StatusEndpointScopeExternal, Type: "Application", URI: "https://my-app.runtime.example.com"},
+			{Name: KnativeTagEndpointPrefix + "preview", Scope: appstacksv1beta2.StatusEndpointScopeExternal, Type: "Application", URI: "https://preview-my-app.runtime.example.com"},
+		}, endpoints},
+	}
+	verifyTests(testCKS, t)
+}
+
+func TestDeleteSvcCertSecret(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	app1 := createRuntimeComponent("app1", namespace, spec)
+	app2 := createRuntimeComponent("app2", namespace, spec)
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, app1)
+	certmanagerv1.AddToScheme(s)
+
+	caIssuerRef := certmanagermetav1.ObjectReference{Name: "rco-ca-issuer"}
+	meta := func(n string) metav1.ObjectMeta { return metav1.ObjectMeta{Name: n, Namespace: namespace} }
+	objs := []runtime.Object{
+		app1, app2,
+		&certmanagerv1.Issuer{ObjectMeta: meta("rco-self-signed")},
+		&certmanagerv1.Issuer{ObjectMeta: meta("rco-ca-issuer")},
+		&certmanagerv1.Certificate{ObjectMeta: meta("rco-ca-cert")},
+		&corev1.Secret{ObjectMeta: meta("rco-ca-tls")},
+		&certmanagerv1.Certificate{ObjectMeta: meta("app1-svc-tls-cm"), Spec: certmanagerv1.CertificateSpec{IssuerRef: caIssuerRef}},
+		&corev1.Secret{ObjectMeta: meta("app1-svc-tls-cm")},
+		&certmanagerv1.Certificate{ObjectMeta: meta("app2-svc-tls-cm"), Spec: certmanagerv1.CertificateSpec{IssuerRef: caIssuerRef}},
+		&corev1.Secret{ObjectMeta: meta("app2-svc-tls-cm")},
+	}
+	cl := fakeclient.NewFakeClientWithScheme(s, objs...)
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+	r.SetDiscoveryClient(createFakeDiscoveryClient(certManagerResources))
+
+	exists := func(obj client.Object, n string) bool {
+		return r.GetClient().Get(context.TODO(), types.NamespacedName{Name: n, Namespace: namespace}, obj) == nil
+	}
+
+	err1 := r.DeleteSvcCertSecret(app1, "rco")
+	app1CertDeleted := !exists(&certmanagerv1.Certificate{}, "app1-svc-tls-cm") && !exists(&corev1.Secret{}, "app1-svc-tls-cm")
+	sharedKept := exists(&certmanagerv1.Issuer{}, "rco-ca-issuer") && exists(&certmanagerv1.Certificate{}, "rco-ca-cert")
+
+	err2 := r.DeleteSvcCertSecret(app2, "rco")
+	sharedDeleted := !exists(&certmanagerv1.Issuer{}, "rco-ca-issuer") && !exists(&certmanagerv1.Issuer{}, "rco-self-signed") &&
+		!exists(&certmanagerv1.Certificate{}, "rco-ca-cert") && !exists(&corev1.Secret{}, "rco-ca-tls")
+
+	testDSCS := []Test{
+		{"Delete first service certificate error", nil, err1},
+		{"First service certificate and secret deleted", true, app1CertDeleted},
+		{"Shared issuers kept while still in use", true, sharedKept},
+		{"Delete last service certificate error", nil, err2},
+		{"Shared issuers deleted with last certificate", true, sharedDeleted},
+	}
+	verifyTests(testDSCS, t)
+}
+
+func TestReconcileRouteCertificate(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	runtime := createRuntimeComponent(name, namespace, spec)
+	runtime.Spec.Service = service
+	runtime.Spec.Route = &appstacksv1beta2.RuntimeComponentRoute{
+		Host:        "my-app.mycompany.com",
+		Certificate: &appstacksv1beta2.RuntimeComponentRouteCertificate{IssuerRef: appstacksv1beta2.RuntimeComponentIssuerRef{Name: "letsencrypt"}},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+	certmanagerv1.AddToScheme(s)
+
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime)
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+	r.SetDiscoveryClient(createFakeDiscoveryClient(certManagerResources))
+	certKey := types.NamespacedName{Name: name + "-route-tls-cm", Namespace: namespace}
+
+	errCreate := r.ReconcileRouteCertificate(runtime, true)
+	cert := &certmanagerv1.Certificate{}
+	cl.Get(context.TODO(), certKey, cert)
+	notReadySecret := GetRouteTLSSecretName(runtime)
+
+	cert.Status.Conditions = []certmanagerv1.CertificateCondition{{Type: certmanagerv1.CertificateConditionReady, Status: certmanagermetav1.ConditionTrue}}
+	cl.Update(context.TODO(), cert)
+	errReady := r.ReconcileRouteCertificate(runtime, true)
+	readySecret := GetRouteTLSSecretName(runtime)
+
+	cl.Create(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: certKey.Name, Namespace: namespace}})
+	errDelete := r.ReconcileRouteCertificate(runtime, false)
+	certDeleted := apierrors.IsNotFound(cl.Get(context.TODO(), certKey, &certmanagerv1.Certificate{}))
+	secretDeleted := apierrors.IsNotFound(cl.Get(context.TODO(), certKey, &corev1.Secret{}))
+
+	testRRC := []Test{
+		{"Create certificate error", nil, errCreate},
+		{"Certificate DNS names", []string{"my-app.mycompany.com"}, cert.Spec.DNSNames},
+		{"Certificate issuer kind", certmanagerv1.ClusterIssuerKind, cert.Spec.IssuerRef.Kind},
+		{"No TLS secret before the certificate is ready", "", notReadySecret},
+		{"Ready certificate error", nil, errReady},
+		{"TLS secret once the certificate is ready", certKey.Name, readySecret},
+		{"Delete certificate error", nil, errDelete},
+		{"Certificate deleted", true, certDeleted},
+		{"Certificate secret deleted", true, secretDeleted},
+	}
+	verifyTests(testRRC, t)
+}
+
+func TestReconcileMTLS(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	mtls := true
+	runtime := createRuntimeComponent(name, namespace, spec)
+	runtime.Spec.Service = &appstacksv1beta2.RuntimeComponentService{Port: 8443, MTLS: &mtls}
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+	certmanagerv1.AddToScheme(s)
+
+	caSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "rco-ca-tls", Namespace: namespace}, Data: map[string][]byte{"ca.crt": []byte(caCrt)}}
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime, caSecret)
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+	r.SetDiscoveryClient(createFakeDiscoveryClient(certManagerResources))
+	certKey := types.NamespacedName{Name: name + "-client-tls-cm", Namespace: namespace}
+
+	errEnable := r.ReconcileMTLS(runtime, "rco", "runtime-component-operator", true)
+	clientCert := &certmanagerv1.Certificate{}
+	cl.Get(context.TODO(), certKey, clientCert)
+	bundle := &corev1.ConfigMap{}
+	cl.Get(context.TODO(), types.NamespacedName{Name: "rco-trust-bundle", Namespace: namespace}, bundle)
+	clientRef := runtime.Status.GetReferences()[common.StatusReferenceClientCertSecretName]
+	bundleRef := runtime.Status.GetReferences()[common.StatusReferenceTrustBundleName]
+
+	cl.Create(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: certKey.Name, Namespace: namespace}})
+	mtls = false
+	errDisable := r.ReconcileMTLS(runtime, "rco", "runtime-component-operator", true)
+	certDeleted := apierrors.IsNotFound(cl.Get(context.TODO(), certKey, &certmanagerv1.Certificate{}))
+	secretDeleted := apierrors.IsNotFound(cl.Get(context.TODO(), certKey, &corev1.Secret{}))
+
+	testRMTLS := []Test{
+		{"Enable mutual TLS error", nil, errEnable},
+		{"Client certificate issuer", "rco-ca-issuer", clientCert.Spec.IssuerRef.Name},
+		{"Client certificate usages", []certmanagerv1.KeyUsage{certmanagerv1.UsageClientAuth, certmanagerv1.UsageDigitalSignature, certmanagerv1.UsageKeyEncipherment}, clientCert.Spec.Usages},
+		{"Trust bundle CA", caCrt, bundle.Data["ca.crt"]},
+		{"Client certificate reference", certKey.Name, clientRef},
+		{"Trust bundle reference", "rco-trust-bundle", bundleRef},
+		{"Disable mutual TLS error", nil, errDisable},
+		{"Client certificate deleted", true, certDeleted},
+		{"Client certificate secret deleted", true, secretDeleted},
+		{"Client certificate reference removed", "", runtime.Status.GetReferences()[common.StatusReferenceClientCertSecretName]},
+	}
+	verifyTests(testRMTLS, t)
+}
+
+func TestReconcileBindingsConsume(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	bindable := true
+	inventory := createRuntimeComponent("inventory", namespace, appstacksv1beta2.RuntimeComponentSpec{
+		Service: &appstacksv1beta2.RuntimeComponentService{Port: 9443, Bindable: &bindable}})
+	runtime := createRuntimeComponent(name, namespace, spec)
+	runtime.Spec.Service = service
+	runtime.Spec.Bindings = []appstacksv1beta2.RuntimeComponentBinding{
+		{Name: "inventory", Service: appstacksv1beta2.RuntimeComponentBindingService{Name: "inventory"}},
+		{Name: "db", Service: appstacksv1beta2.RuntimeComponentBindingService{APIVersion: "v1", Kind: "Secret", Name: "db-credentials"}},
+		{Name: "cache", Service: appstacksv1beta2.RuntimeComponentBindingService{APIVersion: "example.com/v1", Kind: "Cache", Name: "session-cache"}},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+
+	// A Provisioned Service of a kind that the operator does not watch is read from the API server
+	provisioned := &unstructured.Unstructured{}
+	provisioned.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Cache"})
+	provisioned.SetName("session-cache")
+	provisioned.SetNamespace(namespace)
+	unstructured.SetNestedField(provisioned.Object, "session-cache-binding", "status", "binding", "name")
+	cacheSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "session-cache-binding", Namespace: namespace}}
+
+	dbSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: namespace}}
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime, inventory, dbSecret, cacheSecret)
+	rcl := fakeclient.NewFakeClientWithScheme(s, provisioned)
+	r := NewReconcilerBase(rcl, cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+	r.SetDiscoveryClient(createFakeDiscoveryClient())
+
+	// The inventory component does not provide its binding secret until it is reconciled
+	errNotProvided := r.ReconcileBindings(runtime)
+
+	if err := r.ReconcileBindings(inventory); err != nil {
+		t.Fatalf("ReconcileBindings of the bindable component: (%v)", err)
+	}
+	if err := cl.Status().Update(context.TODO(), inventory); err != nil {
+		t.Fatalf("Update status of the bindable component: (%v)", err)
+	}
+	errResolved := r.ReconcileBindings(runtime)
+	bindings := runtime.Status.Bindings
+
+	deploy := &appsv1.Deployment{}
+	CustomizePodSpec(&deploy.Spec.Template, runtime)
+	errVersions := CustomizePodWithBindings(&deploy.Spec.Template, runtime, cl)
+	cl.Get(context.TODO(), types.NamespacedName{Name: "db-credentials", Namespace: namespace}, dbSecret)
+	env := map[string]string{}
+	for _, e := range deploy.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+
+	testRBC := []Test{
+		{"Binding secret not provided", true, errNotProvided != nil},
+		{"Bindings resolved", nil, errResolved},
+		{"Resolved bindings", []appstacksv1beta2.StatusBinding{{Name: "inventory", SecretName: "inventory-expose-binding"}, {Name: "db", SecretName: "db-credentials"}, {Name: "cache", SecretName: "session-cache-binding"}}, bindings},
+		{"Binding secret resource versions error", nil, errVersions},
+		{"Binding secret resource version", dbSecret.ResourceVersion, env["BINDING_DB_SECRET_RESOURCE_VERSION"]},
+	}
+	verifyTests(testRBC, t)
+}
+
+func TestGetIngressEndpointsGateway(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	expose := true
+	runtime := createRuntimeComponent(name, namespace, spec)
+	runtime.Spec.Service = service
+	runtime.Spec.Expose = &expose
+	runtime.Spec.Route = &appstacksv1beta2.RuntimeComponentRoute{Host: "my-app.example.com",
+		Gateway: &appstacksv1beta2.RuntimeComponentGateway{Name: "my-gateway", Namespace: "gateways", SectionName: "https"}}
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+
+	httpRoute := &unstructured.Unstructured{}
+	httpRoute.SetName(name)
+	httpRoute.SetNamespace(namespace)
+	CustomizeHTTPRoute(httpRoute, runtime)
+
+	// The shared Gateway is only readable from the API server
+	gateway := &unstructured.Unstructured{}
+	gateway.SetGroupVersionKind(GatewayGVK)
+	gateway.SetName("my-gateway")
+	gateway.SetNamespace("gateways")
+	unstructured.SetNestedSlice(gateway.Object, []interface{}{
+		map[string]interface{}{"name": "http", "protocol": "HTTP"},
+		map[string]interface{}{"name": "https", "protocol": "HTTPS"},
+	}, "spec", "listeners")
+
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime, httpRoute)
+	r := NewReconcilerBase(fakeclient.NewFakeClientWithScheme(s, gateway), cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+	r.SetDiscoveryClient(createFakeDiscoveryClient(&metav1.APIResourceList{
+		GroupVersion: HTTPRouteGVK.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: "httproutes", Namespaced: true, Kind: HTTPRouteGVK.Kind}},
+	}))
+	errHTTPS := r.ReconcileGatewayProtocol(runtime, true)
+	httpsEndpoints := r.GetIngressEndpoints(runtime)
+
+	runtime.Spec.Route.Gateway.SectionName = "http"
+	errHTTP := r.ReconcileGatewayProtocol(runtime, true)
+	httpEndpoints := r.GetIngressEndpoints(runtime)
+
+	// A Gateway that cannot be read fails the reconcile, and the endpoints keep the recorded protocol
+	runtime.Spec.Route.Gateway.Name = "missing-gateway"
+	errMissing := r.ReconcileGatewayProtocol(runtime, true)
+	missingEndpoints := r.GetIngressEndpoints(runtime)
+	reconciled := runtime.Status.GetCondition(common.StatusConditionTypeReconciled)
+
+	errNotExposed := r.ReconcileGatewayProtocol(runtime, false)
+	_, recorded := runtime.Status.GetReferences()[common.StatusReferenceGatewayProtocol]
+
+	testGIE := []Test{
+		{"HTTPS listener error", nil, errHTTPS},
+		{"HTTPS listener endpoints", []string{"https://my-app.example.com/"}, httpsEndpoints},
+		{"HTTP listener error", nil, errHTTP},
+		{"HTTP listener endpoints", []string{"http://my-app.example.com/"}, httpEndpoints},
+		{"Missing Gateway reported", true, errMissing != nil && strings.Contains(errMissing.Error(), "gateways/missing-gateway")},
+		{"Missing Gateway endpoints", []string{"http://my-app.example.com/"}, missingEndpoints},
+		{"Endpoints do not change the conditions", nil, reconciled},
+		{"Not exposed error", nil, errNotExposed},
+		{"Not exposed protocol removed", false, recorded},
+	}
+	verifyTests(testGIE, t)
+}
+
+func TestReplicaStatus(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	runtime := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: appImage, Service: service})
+	runtime.Initialize()
+	defaultReplicas := runtime.Spec.Replicas
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	CustomizeDeployment(deployment, runtime)
+	deployment.Status = appsv1.DeploymentStatus{Replicas: 2, ReadyReplicas: 1, UpdatedReplicas: 2}
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime, deployment)
+	recorder := record.NewFakeRecorder(10)
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, recorder)
+	r.SetDiscoveryClient(createFakeDiscoveryClient())
+
+	r.CheckResourcesStatus(runtime)
+	replicas, readyReplicas, selector := runtime.Status.Replicas, runtime.Status.ReadyReplicas, runtime.Status.Selector
+
+	// A scale request while an autoscaler manages the pods is reported once
+	scaled := int32(5)
+	runtime.Spec.Replicas = &scaled
+	runtime.Spec.Autoscaling = &appstacksv1beta2.RuntimeComponentAutoScaling{MaxReplicas: 3}
+	r.ReportIgnoredReplicas(runtime)
+	r.ReportIgnoredReplicas(runtime)
+	ignoredEvents := len(recorder.Events)
+	ignoredCondition := *runtime.Status.GetCondition(common.StatusConditionTypeReplicasIgnored).(*appstacksv1beta2.StatusCondition)
+	scaled = 4
+	r.ReportIgnoredReplicas(runtime)
+	rescaledEvents := len(recorder.Events)
+	runtime.Spec.Autoscaling = nil
+	r.ReportIgnoredReplicas(runtime)
+
+	testRS := []Test{
+		{"Default replicas", int32(1), *defaultReplicas},
+		{"Status replicas", int32(2), replicas},
+		{"Status ready replicas", int32(1), readyReplicas},
+		{"Status selector", "app.kubernetes.io/instance=" + name, selector},
+		{"Ignored replicas event", 1, ignoredEvents},
+		{"Ignored replicas condition", corev1.ConditionTrue, ignoredCondition.Status},
+		{"Ignored replicas condition message", true, strings.Contains(ignoredCondition.Message, "spec.replicas 5 is ignored")},
+		{"Ignored replicas event after scale", 2, rescaledEvents},
+		{"Applied replicas condition", corev1.ConditionFalse, runtime.Status.GetCondition(common.StatusConditionTypeReplicasIgnored).GetStatus()},
+	}
+	verifyTests(testRS, t)
+}
+
+func TestComponentMetrics(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	runtime := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: appImage, Service: service})
+	runtime.Generation = 1
+	runtime.CreationTimestamp = metav1.NewTime(time.Now().Add(-10 * time.Second))
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime)
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10))
+	r.SetDiscoveryClient(createFakeDiscoveryClient())
+
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	DeleteComponentMetrics(key)
+	defer DeleteComponentMetrics(key)
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	r.CreateOrUpdate(svc, runtime, func() error { return nil })
+	r.CreateOrUpdate(cm, runtime, func() error { return nil })
+	generated := len(componentMetrics.components[key].resources)
+	r.DeleteResource(cm)
+	generatedAfterDelete := len(componentMetrics.components[key].resources)
+
+	errorsBefore := &dto.Metric{}
+	reconcileErrors.WithLabelValues(ReasonValidationFailed).Write(errorsBefore)
+	r.ManageError(createValidationError(requiredFieldMessage("spec.applicationImage")), common.StatusConditionTypeReconciled, runtime)
+	errorsAfter := &dto.Metric{}
+	reconcileErrors.WithLabelValues(ReasonValidationFailed).Write(errorsAfter)
+	errorReason := runtime.Status.GetCondition(common.StatusConditionTypeReconciled).GetReason()
+	notReady := componentMetrics.components[key].ready
+
+	readyBefore := &dto.Metric{}
+	timeToReady.Write(readyBefore)
+	for _, conditionType := range []common.StatusConditionType{common.StatusConditionTypeReconciled, common.StatusConditionTypeResourcesReady, common.StatusConditionTypeReady} {
+		c := runtime.Status.NewCondition(conditionType)
+		c.SetConditionFields("", "", corev1.ConditionTrue)
+		runtime.Status.SetCondition(c)
+	}
+	componentMetrics.observeStatus(runtime)
+	componentMetrics.observeStatus(runtime)
+	readyAfter := &dto.Metric{}
+	timeToReady.Write(readyAfter)
+
+	testCM := []Test{
+		{"Generated resources", 2, generated},
+		{"Generated resources after delete", 1, generatedAfterDelete},
+		{"Reconcile errors", errorsBefore.GetCounter().GetValue() + 1, errorsAfter.GetCounter().GetValue()},
+		{"Reconcile error reason", ReasonValidationFailed, errorReason},
+		{"Not ready", false, notReady},
+		{"Ready", true, componentMetrics.components[key].ready && componentMetrics.components[key].resourcesReady},
+		{"Time to ready observed once", readyBefore.GetHistogram().GetSampleCount() + 1, readyAfter.GetHistogram().GetSampleCount()},
+		{"Time to ready since creation", true, readyAfter.GetHistogram().GetSampleSum()-readyBefore.GetHistogram().GetSampleSum() >= 10},
+	}
+	verifyTests(testCM, t)
+}
+
+func TestErrorReason(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	cl := fakeclient.NewFakeClient()
+	r := NewReconcilerBase(cl, cl, scheme.Scheme, &rest.Config{}, record.NewFakeRecorder(10))
+	r.SetDiscoveryClient(&failingDiscovery{
+		FakeDiscovery: createFakeDiscoveryClient().(*fakediscovery.FakeDiscovery),
+		groupVersion:  routev1.SchemeGroupVersion.String(),
+	})
+	_, errDiscovery := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
+
+	testER := []Test{
+		{"Validation error", ReasonValidationFailed, ErrorReason(createValidationError("invalid"))},
+		{"Discovery error", ReasonDiscoveryFailed, ErrorReason(fmt.Errorf("failed to reconcile Route: %w", errDiscovery))},
+		{"API not supported error", ReasonAPINotSupported, ErrorReason(fmt.Errorf("failed to reconcile ScaledObject as keda.sh/v1alpha1 is %w", ErrNotSupported))},
+		{"Dependency not ready error", ReasonDependencyNotReady, ErrorReason(fmt.Errorf("Certificate is %w", ErrNotReady))},
+		{"API server error", string(metav1.StatusReasonConflict), ErrorReason(fmt.Errorf("failed to apply: %w", apierrors.NewConflict(schema.GroupResource{Resource: "services"}, name, fmt.Errorf("conflict"))))},
+		{"Other error", "", ErrorReason(fmt.Errorf("failed"))},
+	}
+	verifyTests(testER, t)
+}
+
+func TestCheckCertificatesStatus(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	runtime := createRuntimeComponent(name, namespace, spec)
+	runtime.Spec.Service = &appstacksv1beta2.RuntimeComponentService{Port: 8443}
+	runtime.Status.SetReference(common.StatusReferenceCertSecretName, "my-app-svc-tls")
+	runtime.Status.SetReference(common.StatusReferenceClientCertSecretName, "my-app-client-tls")
+	s := scheme.Scheme
+	s.AddKnownTypes(appstacksv1beta2.GroupVersion, runtime)
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status: appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1, UpdatedReplicas: 1}}
+	svcSecret := makeTLSSecret(t, "my-app-svc-tls", time.Now().Add(-time.Hour), time.Now().AddDate(1, 0, 0))
+	clientSecret := makeTLSSecret(t, "my-app-client-tls", time.Now().Add(-time.Hour), time.Now().AddDate(0, 0, 5))
+	cl := fakeclient.NewFakeClientWithScheme(s, runtime, deployment, svcSecret, clientSecret)
+	recorder := record.NewFakeRecorder(10)
+	r := NewReconcilerBase(cl, cl, s, &rest.Config{}, recorder)
+	r.SetDiscoveryClient(createFakeDiscoveryClient())
+
+	r.CheckCertificatesStatus(runtime)
+	certs := runtime.Status.Certificates
+	expiringCondition := *runtime.Status.GetCondition(common.StatusConditionTypeCertificatesReady).(*appstacksv1beta2.StatusCondition)
+	expiringEvents := len(recorder.Events)
+
+	// An expired service certificate makes the resources not ready
+	expiredSecret := makeTLSSecret(t, "my-app-svc-tls", time.Now().AddDate(0, 0, -10), time.Now().Add(-time.Hour))
+	cl.Update(context.TODO(), expiredSecret)
+	r.CheckCertificatesStatus(runtime)
+	r.CheckResourcesStatus(runtime)
+	expiredCondition := *runtime.Status.GetCondition(common.StatusConditionTypeCertificatesReady).(*appstacksv1beta2.StatusCondition)
+	resourcesCondition := runtime.Status.GetCondition(common.StatusConditionTypeResourcesReady)
+
+	testCCS := []Test{
+		{"Reported certificates", 2, len(certs)},
+		{"Service certificate ready", true, certs[0].Ready},
+		{"Service certificate issuer", "test-ca", certs[0].Issuer},
+		{"Client certificate expires soon", true, certs[1].Ready && certs[1].Message != ""},
+		{"Expiring certificate condition", corev1.ConditionTrue, expiringCondition.Status},
+		{"Expiring certificate reason", "CertificateExpiringSoon", expiringCondition.Reason},
+		{"Expiring certificate event", 1, expiringEvents},
+		{"Expired certificate condition", corev1.ConditionFalse, expiredCondition.Status},
+		{"Expired certificate reason", "CertificateNotReady", expiredCondition.Reason},
+		{"Resources not ready with an expired certificate", corev1.ConditionFalse, resourcesCondition.GetStatus()},
+		{"Resources not ready reason", "CertificateNotReady", resourcesCondition.GetReason()},
+	}
+	verifyTests(testCCS, t)
+}
+
+// makeTLSSecret returns a secret with a self-signed certificate valid between the given times
+func makeTLSSecret(t *testing.T, n string, notBefore time.Time, notAfter time.Time) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-ca"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: namespace},
+		Data:       map[string][]byte{"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})},
+	}
+}
+
 // testGetSvcTLSValues test part of the function GetRouteTLSValues in reconciler.go.
 func testGetSvcTLSValues(t *testing.T) {
 	// Configure the runtime component
@@ -334,7 +1067,43 @@ func TestGetRouteTLSValues(t *testing.T) {
 	testGetRouteTLSValues(t)
 }
 
-func createFakeDiscoveryClient() discovery.DiscoveryInterface {
+// applyRecorder records the server-side apply requests, which the fake client does not support, and returns err for them
+type applyRecorder struct {
+	client.Client
+	patches []map[string]interface{}
+	force   []bool
+	err     error
+}
+
+func (c *applyRecorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+	patchOpts := &client.PatchOptions{}
+	patchOpts.ApplyOptions(opts)
+	c.patches = append(c.patches, body)
+	c.force = append(c.force, patchOpts.Force != nil && *patchOpts.Force)
+	return c.err
+}
+
+// certManagerResources are the resources of cert-manager for createFakeDiscoveryClient
+var certManagerResources = &metav1.APIResourceList{
+	GroupVersion: certmanagerv1.SchemeGroupVersion.String(),
+	APIResources: []metav1.APIResource{
+		{Name: "certificates", Namespaced: true, Kind: "Certificate"},
+	},
+}
+
+// createFakeDiscoveryClient returns a discovery client of a cluster with Routes, Knative services and the given resources
+func createFakeDiscoveryClient(resources ...*metav1.APIResourceList) discovery.DiscoveryInterface {
 	fakeDiscoveryClient := &fakediscovery.FakeDiscovery{Fake: &coretesting.Fake{}}
 	fakeDiscoveryClient.Resources = []*metav1.APIResourceList{
 		{
@@ -350,6 +1119,7 @@ func createFakeDiscoveryClient() discovery.DiscoveryInterface {
 			},
 		},
 	}
+	fakeDiscoveryClient.Resources = append(fakeDiscoveryClient.Resources, resources...)
 
 	return fakeDiscoveryClient
 }
@@ -0,0 +1,276 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	"github.com/application-stacks/runtime-component-operator/common"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// String constants
+const (
+	CanarySuffix = "-canary"
+
+	RolloutStrategyCanary    = "Canary"
+	RolloutStrategyBlueGreen = "BlueGreen"
+
+	NginxCanaryAnnotation       = "nginx.ingress.kubernetes.io/canary"
+	NginxCanaryWeightAnnotation = "nginx.ingress.kubernetes.io/canary-weight"
+)
+
+// GetCanaryName returns the name of the canary Deployment, Service and Ingress of a component
+func GetCanaryName(ba common.BaseComponent) string {
+	return ba.(metav1.Object).GetName() + CanarySuffix
+}
+
+// IsCanaryActive returns whether the canary Deployment of a component must exist
+func IsCanaryActive(rc *appstacksv1beta2.RuntimeComponent) bool {
+	if rc.Spec.Rollout == nil || rc.Status.Rollout == nil {
+		return false
+	}
+	phase := rc.Status.Rollout.Phase
+	return phase == appstacksv1beta2.RolloutPhaseProgressing || phase == appstacksv1beta2.RolloutPhasePromoting
+}
+
+// AdvanceRollout moves the rollout of the image in status.imageReference to its next phase or step.
+// deployedImage is the image of the existing stable Deployment, if any. stableReady and canaryReady
+// report whether all the pods of the stable and canary Deployments run their image and are ready.
+func AdvanceRollout(rc *appstacksv1beta2.RuntimeComponent, deployedImage string, stableReady, canaryReady bool, now metav1.Time) {
+	ro := rc.GetRollout()
+	if ro == nil {
+		rc.Status.Rollout = nil
+		return
+	}
+
+	image := rc.Status.ImageReference
+	status := rc.Status.Rollout
+	if status == nil || status.StableImage == "" {
+		// Keep the image the Deployment already runs, so that a new image set together with spec.rollout is rolled out
+		stableImage := deployedImage
+		if stableImage == "" {
+			stableImage = image
+		}
+		status = &appstacksv1beta2.StatusRollout{Phase: appstacksv1beta2.RolloutPhaseCompleted, StableImage: stableImage}
+		rc.Status.Rollout = status
+	}
+
+	switch status.Phase {
+	case appstacksv1beta2.RolloutPhaseProgressing:
+		if image != status.CanaryImage {
+			startRollout(status, ro, image, now)
+		} else if ro.GetAbort() {
+			abortRollout(status, "The rollout was aborted by spec.rollout.abort")
+		} else if !canaryReady {
+			if status.StepStartTime != nil && now.Sub(status.StepStartTime.Time) > time.Duration(ro.GetProgressDeadlineSeconds())*time.Second {
+				abortRollout(status, fmt.Sprintf("The canary Deployment was not ready within %d seconds", ro.GetProgressDeadlineSeconds()))
+			} else {
+				status.Message = fmt.Sprintf("Waiting for the canary Deployment to be ready in step %d", status.Step+1)
+			}
+		} else if status.StepStartTime == nil || !now.Time.Before(status.StepStartTime.Add(time.Duration(getRolloutStepPause(ro, status.Step))*time.Second)) {
+			if status.Step+1 < getRolloutStepCount(ro) {
+				status.Step++
+				status.StepStartTime = &now
+				status.Message = fmt.Sprintf("Step %d of %d", status.Step+1, getRolloutStepCount(ro))
+			} else {
+				// Send all traffic to the canary Deployment while the stable Deployment is updated to the new image
+				status.Phase = appstacksv1beta2.RolloutPhasePromoting
+				status.StableImage = status.CanaryImage
+				status.StepStartTime = &now
+				status.Message = "Updating the stable Deployment to " + status.CanaryImage
+			}
+		}
+	case appstacksv1beta2.RolloutPhasePromoting:
+		if stableReady {
+			status.Phase = appstacksv1beta2.RolloutPhaseCompleted
+			status.Message = "Rolled out " + status.StableImage
+			status.CanaryImage = ""
+			status.Step = 0
+			status.StepStartTime = nil
+		}
+	case appstacksv1beta2.RolloutPhaseAborted:
+		if image == status.StableImage {
+			status.Phase = appstacksv1beta2.RolloutPhaseCompleted
+			status.Message = ""
+			status.CanaryImage = ""
+		} else if image != status.CanaryImage && !ro.GetAbort() {
+			startRollout(status, ro, image, now)
+		}
+	default:
+		if image != status.StableImage {
+			startRollout(status, ro, image, now)
+		}
+	}
+
+	status.CanaryWeight = getCanaryWeight(ro, status)
+}
+
+func startRollout(status *appstacksv1beta2.StatusRollout, ro common.BaseComponentRollout, image string, now metav1.Time) {
+	if image == status.StableImage {
+		// The image was reverted to the stable image, there is nothing to roll out anymore
+		status.Phase = appstacksv1beta2.RolloutPhaseCompleted
+		status.Message = ""
+		status.CanaryImage = ""
+		status.Step = 0
+		status.StepStartTime = nil
+		return
+	}
+	status.Phase = appstacksv1beta2.RolloutPhaseProgressing
+	status.CanaryImage = image
+	status.Step = 0
+	status.StepStartTime = &now
+	status.Message = fmt.Sprintf("Step 1 of %d", getRolloutStepCount(ro))
+}
+
+func abortRollout(status *appstacksv1beta2.StatusRollout, msg string) {
+	status.Phase = appstacksv1beta2.RolloutPhaseAborted
+	status.Step = 0
+	status.StepStartTime = nil
+	status.Message = msg
+}
+
+func getRolloutStepCount(ro common.BaseComponentRollout) int32 {
+	if ro.GetStrategy() == RolloutStrategyBlueGreen {
+		return 1
+	}
+	return int32(len(ro.GetSteps()))
+}
+
+func getRolloutStepPause(ro common.BaseComponentRollout, step int32) int32 {
+	if ro.GetStrategy() == RolloutStrategyBlueGreen {
+		return ro.GetAutoPromotionSeconds()
+	}
+	if int(step) >= len(ro.GetSteps()) || ro.GetSteps()[step].GetPauseSeconds() == nil {
+		return 0
+	}
+	return *ro.GetSteps()[step].GetPauseSeconds()
+}
+
+func getCanaryWeight(ro common.BaseComponentRollout, status *appstacksv1beta2.StatusRollout) int32 {
+	switch status.Phase {
+	case appstacksv1beta2.RolloutPhasePromoting:
+		return 100
+	case appstacksv1beta2.RolloutPhaseProgressing:
+		// Blue/green previews receive no traffic until they are promoted
+		if ro.GetStrategy() == RolloutStrategyBlueGreen || int(status.Step) >= len(ro.GetSteps()) {
+			return 0
+		}
+		return ro.GetSteps()[status.Step].GetWeight()
+	}
+	return 0
+}
+
+// IsDeploymentReady returns whether all the pods of a Deployment run the given image and are ready
+func IsDeploymentReady(deploy *appsv1.Deployment, image string) bool {
+	if deploy.Generation != deploy.Status.ObservedGeneration || len(deploy.Spec.Template.Spec.Containers) == 0 {
+		return false
+	}
+	if GetAppContainer(deploy.Spec.Template.Spec.Containers).Image != image {
+		return false
+	}
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	ds := deploy.Status
+	return ds.Replicas == replicas && ds.UpdatedReplicas == replicas && ds.ReadyReplicas == replicas
+}
+
+// CustomizeCanaryDeployment turns a Deployment customized for the component into its canary Deployment.
+// The canary pods get their own instance label, so that the stable Service does not select them.
+func CustomizeCanaryDeployment(deploy *appsv1.Deployment, ba common.BaseComponent, image string) {
+	canaryName := GetCanaryName(ba)
+	deploy.Labels = MergeMaps(deploy.Labels, map[string]string{"app.kubernetes.io/instance": canaryName})
+	deploy.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app.kubernetes.io/instance": canaryName,
+		},
+	}
+	deploy.Spec.Template.Labels = MergeMaps(deploy.Spec.Template.Labels, map[string]string{"app.kubernetes.io/instance": canaryName})
+	GetAppContainer(deploy.Spec.Template.Spec.Containers).Image = image
+
+	// A blue/green preview must be able to take all the traffic of the stable Deployment
+	replicas := ba.GetRollout().GetCanaryReplicas()
+	if ba.GetRollout().GetStrategy() == RolloutStrategyBlueGreen {
+		replicas = 1
+		if ba.GetReplicas() != nil {
+			replicas = *ba.GetReplicas()
+		}
+		if ba.GetAutoscaling() != nil && ba.GetAutoscaling().GetMinReplicas() != nil && *ba.GetAutoscaling().GetMinReplicas() > replicas {
+			replicas = *ba.GetAutoscaling().GetMinReplicas()
+		}
+	}
+	deploy.Spec.Replicas = &replicas
+}
+
+// CustomizeCanaryService customizes the ClusterIP Service that selects the canary pods
+func CustomizeCanaryService(svc *corev1.Service, ba common.BaseComponent) {
+	CustomizeService(svc, ba)
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	for i := range svc.Spec.Ports {
+		svc.Spec.Ports[i].NodePort = 0
+	}
+	svc.Spec.Selector = map[string]string{
+		"app.kubernetes.io/instance": GetCanaryName(ba),
+	}
+}
+
+// SplitRouteTraffic sends the given percentage of the traffic of a Route to the canary Service
+func SplitRouteTraffic(route *routev1.Route, ba common.BaseComponent, canaryWeight int32) {
+	stableWeight := 100 - canaryWeight
+	route.Spec.To.Weight = &stableWeight
+	route.Spec.AlternateBackends = []routev1.RouteTargetReference{
+		{
+			Kind:   "Service",
+			Name:   GetCanaryName(ba),
+			Weight: &canaryWeight,
+		},
+	}
+}
+
+// SplitHTTPRouteTraffic sends the given percentage of the traffic of a Gateway API HTTPRoute to the canary Service
+func SplitHTTPRouteTraffic(route *unstructured.Unstructured, ba common.BaseComponent, canaryWeight int32) {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for i := range rules {
+		rule, ok := rules[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		backendRefs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+		if len(backendRefs) == 0 {
+			continue
+		}
+		port, _, _ := unstructured.NestedInt64(backendRefs[0].(map[string]interface{}), "port")
+		rule["backendRefs"] = []interface{}{
+			gatewayBackendRef(ba.(metav1.Object).GetName(), port, 100-canaryWeight),
+			gatewayBackendRef(GetCanaryName(ba), port, canaryWeight),
+		}
+	}
+	unstructured.SetNestedSlice(route.Object, rules, "spec", "rules")
+}
+
+// CustomizeCanaryIngress customizes an NGINX canary Ingress that sends the given percentage of the traffic of the component's Ingress to the canary Service
+func CustomizeCanaryIngress(ing *networkingv1.Ingress, ba common.BaseComponent, canaryWeight int32) {
+	CustomizeIngress(ing, ba)
+	ing.Annotations = MergeMaps(ing.Annotations, map[string]string{
+		NginxCanaryAnnotation:       "true",
+		NginxCanaryWeightAnnotation: strconv.Itoa(int(canaryWeight)),
+	})
+	for i := range ing.Spec.Rules {
+		if ing.Spec.Rules[i].HTTP == nil {
+			continue
+		}
+		for j := range ing.Spec.Rules[i].HTTP.Paths {
+			if svc := ing.Spec.Rules[i].HTTP.Paths[j].Backend.Service; svc != nil {
+				svc.Name = GetCanaryName(ba)
+			}
+		}
+	}
+}
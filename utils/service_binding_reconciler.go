@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
 	"github.com/application-stacks/runtime-component-operator/common"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -17,16 +22,103 @@ import (
 const (
 	ExposeBindingOverrideSecretSuffix = "-expose-binding-override"
 	ExposeBindingSecretSuffix         = "-expose-binding"
+	ServiceBindingRootEnv             = "SERVICE_BINDING_ROOT"
+	DefaultServiceBindingRoot         = "/bindings"
+
+	// BindingServicesAggregationLabel is the label of the ClusterRoles aggregated into the role that allows the
+	// operator to read the services that components bind to
+	BindingServicesAggregationLabel = "rc.app.stacks/aggregate-to-binding-services"
 )
 
+// bindingServiceTimeout bounds the reads of the services that components bind to from the API server
+const bindingServiceTimeout = 10 * time.Second
+
 // ReconcileBindings goes through the reconcile logic for service binding
 func (r *ReconcilerBase) ReconcileBindings(ba common.BaseComponent) error {
 	if err := r.reconcileExpose(ba); err != nil {
 		return err
 	}
+	if err := r.reconcileConsume(ba); err != nil {
+		return err
+	}
 	return nil
 }
 
+// reconcileConsume resolves the binding secret of each service in spec.bindings and records it in the status,
+// where the pod customization reads it from
+func (r *ReconcilerBase) reconcileConsume(ba common.BaseComponent) error {
+	bindings := []common.StatusBinding{}
+	for _, b := range ba.GetBindings() {
+		secretName, err := r.getBindingSecretName(ba, b.GetService())
+		if err != nil {
+			return fmt.Errorf("failed to resolve binding %s: %w", b.GetName(), err)
+		}
+		bindings = append(bindings, ba.GetStatus().NewStatusBinding(b.GetName(), secretName))
+	}
+	ba.GetStatus().SetBindings(bindings)
+	return nil
+}
+
+// getBindingSecretName returns the name of the binding secret of a service. A Secret is used directly, and any other
+// service must report its binding secret in status.binding.name, as defined for Provisioned Services.
+// RuntimeComponents are read from the cache. Other kinds of services are read from the API server, as the operator
+// does not watch them and might not be allowed to, see bindingServiceTimeout.
+func (r *ReconcilerBase) getBindingSecretName(ba common.BaseComponent, svc common.BaseComponentBindingService) (string, error) {
+	namespace := ba.(metav1.Object).GetNamespace()
+	key := types.NamespacedName{Name: svc.GetName(), Namespace: namespace}
+	gvk := GetBindingServiceGVK(svc)
+
+	secretName := svc.GetName()
+	switch gvk {
+	case corev1.SchemeGroupVersion.WithKind("Secret"):
+	case appstacksv1beta2.GroupVersion.WithKind("RuntimeComponent"):
+		component := &appstacksv1beta2.RuntimeComponent{}
+		if err := r.GetClient().Get(context.TODO(), key, component); err != nil {
+			return "", err
+		}
+		secretName = ""
+		if component.Status.Binding != nil {
+			secretName = component.Status.Binding.Name
+		}
+	default:
+		service := &unstructured.Unstructured{}
+		service.SetGroupVersionKind(gvk)
+		ctx, cancel := context.WithTimeout(context.Background(), bindingServiceTimeout)
+		defer cancel()
+		if err := r.GetAPIReader().Get(ctx, key, service); err != nil {
+			if apierrors.IsForbidden(err) {
+				return "", fmt.Errorf("the operator is not allowed to get %s %s, grant it with a ClusterRole labeled %s=true: %w", gvk.Kind, svc.GetName(), BindingServicesAggregationLabel, err)
+			}
+			return "", err
+		}
+		secretName, _, _ = unstructured.NestedString(service.Object, "status", "binding", "name")
+	}
+	if secretName == "" {
+		return "", fmt.Errorf("%s %s is %w, it does not provide a binding secret in status.binding.name", gvk.Kind, svc.GetName(), ErrNotReady)
+	}
+
+	// The pods cannot start until the binding secret exists
+	if err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, &corev1.Secret{}); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
+
+// GetBindingServiceGVK returns the kind of a service to bind to, which defaults to RuntimeComponent
+func GetBindingServiceGVK(svc common.BaseComponentBindingService) schema.GroupVersionKind {
+	gv := appstacksv1beta2.GroupVersion
+	if svc.GetAPIVersion() != "" {
+		if parsed, err := schema.ParseGroupVersion(svc.GetAPIVersion()); err == nil {
+			gv = parsed
+		}
+	}
+	kind := svc.GetKind()
+	if kind == "" {
+		kind = "RuntimeComponent"
+	}
+	return gv.WithKind(kind)
+}
+
 func (r *ReconcilerBase) reconcileExpose(ba common.BaseComponent) error {
 	mObj := ba.(metav1.Object)
 	bindingSecret := &corev1.Secret{
@@ -18,17 +18,34 @@ package utils
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/application-stacks/runtime-component-operator/common"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Names of the certificates reported in the status
+const (
+	StatusCertificateService = "Service"
+	StatusCertificateRoute   = "Route"
+	StatusCertificateClient  = "Client"
+)
+
 func (r *ReconcilerBase) CheckApplicationStatus(ba common.BaseComponent) corev1.ConditionStatus {
 	s := ba.GetStatus()
 
@@ -43,8 +60,10 @@ func (r *ReconcilerBase) CheckApplicationStatus(ba common.BaseComponent) corev1.
 		reason = "ApplicationNotReconciled"
 	} else {
 		// If reconciled, check resources status and endpoint information
+		r.CheckCertificatesStatus(ba)
 		r.CheckResourcesStatus(ba)
 		r.ReportExternalEndpointStatus(ba)
+		r.ReportKnativeEndpointStatus(ba)
 
 		scReady := s.GetCondition(common.StatusConditionTypeResourcesReady)
 		if scReady == nil || scReady.GetStatus() != corev1.ConditionTrue {
@@ -63,6 +82,7 @@ func (r *ReconcilerBase) CheckApplicationStatus(ba common.BaseComponent) corev1.
 	newCondition.SetConditionFields(msg, reason, status)
 	r.setCondition(ba, oldCondition, newCondition)
 
+	componentMetrics.observeStatus(ba)
 	return status
 }
 
@@ -77,12 +97,130 @@ func (r *ReconcilerBase) CheckResourcesStatus(ba common.BaseComponent) {
 	if ba.GetCreateKnativeService() == nil || !*ba.GetCreateKnativeService() {
 		newCondition = r.areReplicasReady(ba, newCondition)
 	} else {
+		// Knative scales the revisions of the application, which has no replicas of its own
+		ba.GetStatus().SetReplicas(0, 0, "")
 		newCondition = r.isKnativeReady(ba, newCondition)
 	}
 
+	// The resources are not ready to serve clients until their certificates are valid
+	if certCondition := s.GetCondition(common.StatusConditionTypeCertificatesReady); newCondition.GetStatus() == corev1.ConditionTrue &&
+		certCondition != nil && certCondition.GetStatus() != corev1.ConditionTrue {
+		newCondition.SetConditionFields("Certificates are not ready: "+certCondition.GetMessage(), "CertificateNotReady", corev1.ConditionFalse)
+	}
+
 	r.setCondition(ba, oldCondition, newCondition)
 }
 
+// CheckCertificatesStatus reports in the status the state of the service, route and client certificates of the application,
+// read from the tls.crt of their secrets, and sets the CertificatesReady condition. A warning event is emitted when a certificate
+// becomes not ready, or gets close to its expiry.
+func (r *ReconcilerBase) CheckCertificatesStatus(ba common.BaseComponent) {
+	s := ba.GetStatus()
+	refs := s.GetReferences()
+
+	names, secretNames := []string{}, []string{}
+	if secretName := refs[common.StatusReferenceCertSecretName]; secretName != "" {
+		names, secretNames = append(names, StatusCertificateService), append(secretNames, secretName)
+	}
+	if secretName := GetRouteTLSSecretName(ba); secretName != "" && ba.GetExpose() != nil && *ba.GetExpose() {
+		names, secretNames = append(names, StatusCertificateRoute), append(secretNames, secretName)
+	}
+	if secretName := refs[common.StatusReferenceClientCertSecretName]; secretName != "" {
+		names, secretNames = append(names, StatusCertificateClient), append(secretNames, secretName)
+	}
+
+	warningTime := time.Now().AddDate(0, 0, common.GetConfig().CertExpiryWarningDays)
+
+	certificates := []common.StatusCertificate{}
+	notReady, expiring := []string{}, []string{}
+	for i := range names {
+		cert := r.getCertificateStatus(ba, names[i], secretNames[i], warningTime)
+		certificates = append(certificates, cert)
+		if !cert.IsReady() {
+			notReady = append(notReady, cert.GetMessage())
+		} else if cert.GetMessage() != "" {
+			expiring = append(expiring, cert.GetMessage())
+		}
+	}
+	s.SetCertificates(certificates)
+
+	conditionType := common.StatusConditionTypeCertificatesReady
+	oldCondition := s.GetCondition(conditionType)
+	newCondition := s.NewCondition(conditionType)
+	if len(notReady) > 0 {
+		newCondition.SetConditionFields(strings.Join(notReady, " "), "CertificateNotReady", corev1.ConditionFalse)
+	} else if len(expiring) > 0 {
+		newCondition.SetConditionFields(strings.Join(expiring, " "), "CertificateExpiringSoon", corev1.ConditionTrue)
+	} else if len(certificates) > 0 {
+		newCondition.SetConditionFields("Certificates are valid.", "CertificatesValid", corev1.ConditionTrue)
+	} else {
+		newCondition.SetConditionFields("Application does not use certificates.", "NoCertificates", corev1.ConditionTrue)
+	}
+
+	if oldCondition == nil || oldCondition.GetReason() != newCondition.GetReason() || oldCondition.GetMessage() != newCondition.GetMessage() {
+		switch newCondition.GetReason() {
+		case "CertificateNotReady":
+			r.GetRecorder().Event(ba.(client.Object), "Warning", "CertificateNotReady", newCondition.GetMessage())
+		case "CertificateExpiringSoon":
+			r.GetRecorder().Event(ba.(client.Object), "Warning", "CertificateExpiring", newCondition.GetMessage())
+		}
+	}
+	r.setCondition(ba, oldCondition, newCondition)
+}
+
+// getCertificateStatus returns the state of the certificate in the tls.crt of a secret. When the secret belongs to a
+// cert-manager Certificate, the certificate is only ready when cert-manager reports it as ready. The message of a ready
+// certificate is set when the certificate expires before the warning time.
+func (r *ReconcilerBase) getCertificateStatus(ba common.BaseComponent, name string, secretName string, warningTime time.Time) common.StatusCertificate {
+	s := ba.GetStatus()
+	namespace := ba.(metav1.Object).GetNamespace()
+	prefix := fmt.Sprintf("%s certificate in secret %s", name, secretName)
+
+	secret := &corev1.Secret{}
+	if err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		return s.NewStatusCertificate(name, secretName, "", nil, false, fmt.Sprintf("%s is not available: %v.", prefix, err))
+	}
+	block, _ := pem.Decode(secret.Data["tls.crt"])
+	if block == nil {
+		return s.NewStatusCertificate(name, secretName, "", nil, false, fmt.Sprintf("%s has no PEM encoded tls.crt.", prefix))
+	}
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return s.NewStatusCertificate(name, secretName, "", nil, false, fmt.Sprintf("%s cannot be parsed: %v.", prefix, err))
+	}
+
+	issuer := crt.Issuer.CommonName
+	if issuer == "" {
+		issuer = crt.Issuer.String()
+	}
+	notAfter := &metav1.Time{Time: crt.NotAfter}
+	now := time.Now()
+	if now.Before(crt.NotBefore) {
+		return s.NewStatusCertificate(name, secretName, issuer, notAfter, false, fmt.Sprintf("%s is not valid before %s.", prefix, crt.NotBefore.UTC().Format(time.RFC3339)))
+	}
+	if now.After(crt.NotAfter) {
+		return s.NewStatusCertificate(name, secretName, issuer, notAfter, false, fmt.Sprintf("%s expired on %s.", prefix, crt.NotAfter.UTC().Format(time.RFC3339)))
+	}
+
+	if ok, _ := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate"); ok {
+		cert := &certmanagerv1.Certificate{}
+		if err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, cert); err == nil && !isCertificateReady(cert) {
+			msg := fmt.Sprintf("%s is not ready.", prefix)
+			for _, c := range cert.Status.Conditions {
+				if c.Type == certmanagerv1.CertificateConditionReady && c.Message != "" {
+					msg = fmt.Sprintf("%s is not ready: %s", prefix, c.Message)
+				}
+			}
+			return s.NewStatusCertificate(name, secretName, issuer, notAfter, false, msg)
+		}
+	}
+
+	if crt.NotAfter.Before(warningTime) {
+		return s.NewStatusCertificate(name, secretName, issuer, notAfter, true, fmt.Sprintf("%s expires on %s.", prefix, crt.NotAfter.UTC().Format(time.RFC3339)))
+	}
+	return s.NewStatusCertificate(name, secretName, issuer, notAfter, true, "")
+}
+
 func (r *ReconcilerBase) setCondition(ba common.BaseComponent, oldCondition common.StatusCondition, newCondition common.StatusCondition) {
 	s := ba.GetStatus()
 
@@ -93,6 +231,44 @@ func (r *ReconcilerBase) setCondition(ba common.BaseComponent, oldCondition comm
 	}
 }
 
+// ReportIgnoredReplicas sets the ReplicasIgnored condition when spec.replicas, which the scale subresource sets, is ignored
+// because an autoscaler manages the number of pods. A warning event is emitted once for each ignored value.
+func (r *ReconcilerBase) ReportIgnoredReplicas(ba common.BaseComponent) {
+	s := ba.GetStatus()
+	conditionType := common.StatusConditionTypeReplicasIgnored
+	oldCondition := s.GetCondition(conditionType)
+	newCondition := s.NewCondition(conditionType)
+	if ba.GetAutoscaling() == nil || ba.GetReplicas() == nil {
+		if oldCondition != nil {
+			newCondition.SetConditionFields("", "ReplicasApplied", corev1.ConditionFalse)
+			r.setCondition(ba, oldCondition, newCondition)
+		}
+		return
+	}
+
+	autoscaler := "the HorizontalPodAutoscaler"
+	if ba.GetAutoscaling().GetKeda() != nil {
+		autoscaler = "the KEDA ScaledObject"
+	}
+	msg := fmt.Sprintf("spec.replicas %s is ignored as %s scales the application between spec.autoscaling.minReplicas and spec.autoscaling.maxReplicas.",
+		strconv.Itoa(int(*ba.GetReplicas())), autoscaler)
+	newCondition.SetConditionFields(msg, "ReplicasIgnored", corev1.ConditionTrue)
+
+	if oldCondition == nil || oldCondition.GetStatus() != newCondition.GetStatus() || oldCondition.GetMessage() != newCondition.GetMessage() {
+		r.GetRecorder().Event(ba.(client.Object), "Warning", "ReplicasIgnored", msg)
+	}
+	r.setCondition(ba, oldCondition, newCondition)
+}
+
+// getSelectorString returns the label selector of a Deployment or StatefulSet in the string form of the scale subresource
+func getSelectorString(labelSelector *metav1.LabelSelector) string {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return ""
+	}
+	return selector.String()
+}
+
 func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.StatusCondition) common.StatusCondition {
 	obj := ba.(client.Object)
 	namespacedName := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
@@ -114,6 +290,7 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 		deployment := &appsv1.Deployment{}
 		err := r.GetClient().Get(context.TODO(), namespacedName, deployment)
 		if err != nil {
+			ba.GetStatus().SetReplicas(0, 0, "")
 			msg, reason = "Deployment is not ready.", "NotCreated"
 			return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
 		}
@@ -121,11 +298,13 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 		resourceType = "Deployment"
 		ds := deployment.Status
 		replicas, readyReplicas, updatedReplicas = ds.Replicas, ds.ReadyReplicas, ds.UpdatedReplicas
+		ba.GetStatus().SetReplicas(replicas, readyReplicas, getSelectorString(deployment.Spec.Selector))
 	} else {
 		// Check if statefulSet exists
 		statefulSet := &appsv1.StatefulSet{}
 		err := r.GetClient().Get(context.TODO(), namespacedName, statefulSet)
 		if err != nil {
+			ba.GetStatus().SetReplicas(0, 0, "")
 			msg, reason = "StatefulSet is not ready.", "NotCreated"
 			return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
 		}
@@ -133,6 +312,7 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 		resourceType = "StatefulSet"
 		ss := statefulSet.Status
 		replicas, readyReplicas, updatedReplicas = ss.Replicas, ss.ReadyReplicas, ss.UpdatedReplicas
+		ba.GetStatus().SetReplicas(replicas, readyReplicas, getSelectorString(statefulSet.Spec.Selector))
 	}
 
 	// Get replicas that are ready and updated
@@ -151,6 +331,10 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 		autoMaxReplicas := autoScale.GetMaxReplicas()
 		if autoMinReplicas == nil {
 			autoMinReplicas = &minReplicas
+			// KEDA scales the application down to zero replicas by default when its triggers are inactive
+			if autoScale.GetKeda() != nil {
+				autoMinReplicas = new(int32)
+			}
 		}
 		// Check if the replicas are more than min and less than max
 		if readyUpdatedReplicas < *autoMinReplicas {
@@ -161,6 +345,13 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 			reason = "ReplicaSetUpdating"
 			return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
 		}
+		// Report the number of replicas wanted by the autoscaler, which can be anywhere between min and max.
+		// The HorizontalPodAutoscaler of a KEDA ScaledObject is not managed by the operator.
+		if autoScale.GetKeda() == nil {
+			if desiredReplicas := r.getDesiredReplicas(namespacedName); desiredReplicas > 0 {
+				msg = msg + "/" + strconv.Itoa(int(desiredReplicas))
+			}
+		}
 		reason = "MinimumReplicasAvailable"
 		return c.SetConditionFields(msg, reason, corev1.ConditionTrue)
 	}
@@ -178,6 +369,22 @@ func (r *ReconcilerBase) areReplicasReady(ba common.BaseComponent, c common.Stat
 	return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
 }
 
+// getDesiredReplicas returns the number of replicas last calculated by the HorizontalPodAutoscaler, or 0 if it is unknown
+func (r *ReconcilerBase) getDesiredReplicas(namespacedName types.NamespacedName) int32 {
+	if ok, _ := r.IsGroupVersionSupported(autoscalingv2.SchemeGroupVersion.String(), "HorizontalPodAutoscaler"); ok {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := r.GetClient().Get(context.TODO(), namespacedName, hpa); err != nil {
+			return 0
+		}
+		return hpa.Status.DesiredReplicas
+	}
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{}
+	if err := r.GetClient().Get(context.TODO(), namespacedName, hpa); err != nil {
+		return 0
+	}
+	return hpa.Status.DesiredReplicas
+}
+
 func (r *ReconcilerBase) isKnativeReady(ba common.BaseComponent, c common.StatusCondition) common.StatusCondition {
 	knative := &servingv1.Service{}
 	obj := ba.(client.Object)
@@ -191,7 +398,42 @@ func (r *ReconcilerBase) isKnativeReady(ba common.BaseComponent, c common.Status
 		return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
 	}
 
-	msg = "Knative service is ready."
+	// Wait for Knative to process the latest changes to the service
+	if knative.Status.ObservedGeneration != knative.Generation {
+		msg, reason = "Knative service is being updated.", "KnativeServiceUpdating"
+		return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
+	}
+
+	// Report the first Knative condition that is not ready, with its reason and message
+	for _, conditionType := range []apis.ConditionType{servingv1.ServiceConditionConfigurationsReady, servingv1.ServiceConditionRoutesReady, servingv1.ServiceConditionReady} {
+		kc := knative.Status.GetCondition(conditionType)
+		if kc == nil {
+			msg, reason = fmt.Sprintf("Knative service %s condition is not reported yet.", conditionType), "KnativeServiceNotReady"
+			return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
+		}
+		if kc.Status != corev1.ConditionTrue {
+			msg, reason = fmt.Sprintf("Knative service %s condition is %s", conditionType, kc.Status), kc.Reason
+			if kc.Message != "" {
+				msg += ": " + kc.Message
+			}
+			if reason == "" {
+				reason = "KnativeServiceNotReady"
+			}
+			return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
+		}
+	}
+
+	// The latest revision is still starting when it differs from the latest ready revision
+	if knative.Status.LatestCreatedRevisionName != knative.Status.LatestReadyRevisionName {
+		msg = fmt.Sprintf("Latest revision %s is not ready.", knative.Status.LatestCreatedRevisionName)
+		if knative.Status.LatestReadyRevisionName != "" {
+			msg = fmt.Sprintf("Latest revision %s is not ready, latest ready revision is %s.", knative.Status.LatestCreatedRevisionName, knative.Status.LatestReadyRevisionName)
+		}
+		reason = "RevisionNotReady"
+		return c.SetConditionFields(msg, reason, corev1.ConditionFalse)
+	}
+
+	msg = fmt.Sprintf("Knative service is ready. Latest ready revision is %s.", knative.Status.LatestReadyRevisionName)
 	return c.SetConditionFields(msg, reason, corev1.ConditionTrue)
 }
 
@@ -200,29 +442,95 @@ func (r *ReconcilerBase) ReportExternalEndpointStatus(ba common.BaseComponent) {
 	s := ba.GetStatus()
 
 	// If application not exposed or uses Knative service, remove route/ingress endpoint information
-	if ba.GetExpose() == nil || !*ba.GetExpose() || (ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService()) {
-		s.RemoveStatusEndpoint(name)
-		return
+	endpoints := []string{}
+	if ba.GetExpose() != nil && *ba.GetExpose() && (ba.GetCreateKnativeService() == nil || !*ba.GetCreateKnativeService()) {
+		endpoints = r.GetIngressEndpoints(ba)
 	}
 
-	host, path, protocol := r.GetIngressInfo(ba)
-	// If route/ingress host is empty, host is set to wildcard
-	if host == "" {
-		host = "*"
+	// The first endpoint is the host and path of spec.route, the others are its additional paths
+	names := map[string]bool{}
+	for i, endpoint := range endpoints {
+		endpointName := name
+		if i > 0 {
+			endpointName = name + "-" + strconv.Itoa(i)
+		}
+		names[endpointName] = true
+
+		oldEndpoint := s.GetStatusEndpoint(endpointName)
+		newEndpoint := s.NewStatusEndpoint(endpointName)
+
+		// Check if endpoint information has been changed
+		if oldEndpoint == nil || oldEndpoint.GetEndpointUri() != endpoint {
+			// Set endpoint information fields and update status
+			endpointType := "Application"
+			endpointScope := common.StatusEndpointScopeExternal
+
+			newEndpoint.SetStatusEndpointFields(endpointScope, endpointType, endpoint)
+			s.SetStatusEndpoint(newEndpoint)
+		}
 	}
 
-	endpoint := fmt.Sprintf("%s://%s%s", protocol, host, path)
+	stale := []string{}
+	for _, e := range s.GetStatusEndpoints() {
+		endpointName := e.GetEndpointName()
+		if (endpointName == name || strings.HasPrefix(endpointName, name+"-")) && !names[endpointName] {
+			stale = append(stale, endpointName)
+		}
+	}
+	for _, endpointName := range stale {
+		s.RemoveStatusEndpoint(endpointName)
+	}
+}
 
-	oldEndpoint := s.GetStatusEndpoint(name)
-	newEndpoint := s.NewStatusEndpoint(name)
+// ReportKnativeEndpointStatus sets the endpoints of the Knative service URL and of the URLs of its tagged traffic targets
+func (r *ReconcilerBase) ReportKnativeEndpointStatus(ba common.BaseComponent) {
+	s := ba.GetStatus()
 
-	// Check if endpoint information has been changed
-	if oldEndpoint == nil || oldEndpoint.GetEndpointUri() != endpoint {
-		// Set endpoint information fields and update status
-		endpointType := "Application"
-		endpointScope := common.StatusEndpointScopeExternal
+	urls := map[string]string{}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		ksvc := &servingv1.Service{}
+		obj := ba.(client.Object)
+		if err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, ksvc); err == nil {
+			if ksvc.Status.URL != nil {
+				urls[KnativeEndpointName] = ksvc.Status.URL.String()
+			}
+			for _, t := range ksvc.Status.Traffic {
+				if t.Tag != "" && t.URL != nil {
+					urls[KnativeTagEndpointPrefix+t.Tag] = t.URL.String()
+				}
+			}
+		}
+	}
 
-		newEndpoint.SetStatusEndpointFields(endpointScope, endpointType, endpoint)
+	endpointScope := common.StatusEndpointScopeInternal
+	if ba.GetExpose() != nil && *ba.GetExpose() {
+		endpointScope = common.StatusEndpointScopeExternal
+	}
+	names := make([]string, 0, len(urls))
+	for name := range urls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		endpoint := urls[name]
+		oldEndpoint := s.GetStatusEndpoint(name)
+		if oldEndpoint != nil && oldEndpoint.GetEndpointUri() == endpoint && oldEndpoint.GetEndpointScope() == endpointScope {
+			continue
+		}
+		newEndpoint := s.NewStatusEndpoint(name)
+		newEndpoint.SetStatusEndpointFields(endpointScope, "Application", endpoint)
 		s.SetStatusEndpoint(newEndpoint)
 	}
+
+	// Remove the endpoints of the Knative service and of tags that are not routed anymore
+	stale := []string{}
+	for _, e := range s.GetStatusEndpoints() {
+		name := e.GetEndpointName()
+		if (name == KnativeEndpointName || strings.HasPrefix(name, KnativeTagEndpointPrefix)) && urls[name] == "" {
+			stale = append(stale, name)
+		}
+	}
+	for _, name := range stale {
+		s.RemoveStatusEndpoint(name)
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,16 +23,23 @@ import (
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
@@ -107,8 +115,8 @@ func CustomizeRoute(route *routev1.Route, ba common.BaseComponent, key string, c
 		route.Annotations = MergeMaps(route.Annotations, rt.GetAnnotations())
 
 		host := rt.GetHost()
-		if host == "" && common.Config[common.OpConfigDefaultHostname] != "" {
-			host = obj.GetName() + "-" + obj.GetNamespace() + "." + common.Config[common.OpConfigDefaultHostname]
+		if defaultHostname := common.GetConfig().DefaultHostname; host == "" && defaultHostname != "" {
+			host = obj.GetName() + "-" + obj.GetNamespace() + "." + defaultHostname
 		}
 		route.Spec.Host = host
 		route.Spec.Path = rt.GetPath()
@@ -162,6 +170,7 @@ func CustomizeRoute(route *routev1.Route, ba common.BaseComponent, key string, c
 	route.Spec.To.Name = obj.GetName()
 	weight := int32(100)
 	route.Spec.To.Weight = &weight
+	route.Spec.AlternateBackends = nil
 	if route.Spec.Port == nil {
 		route.Spec.Port = &routev1.RoutePort{}
 	}
@@ -173,6 +182,33 @@ func CustomizeRoute(route *routev1.Route, ba common.BaseComponent, key string, c
 	}
 }
 
+// GetAdditionalRouteName returns the name of the Route that exposes the additional path of spec.route with the given index
+func GetAdditionalRouteName(ba common.BaseComponent, i int) string {
+	return ba.(metav1.Object).GetName() + "-path-" + strconv.Itoa(i+1)
+}
+
+// GetAdmittedRouteHost returns the host of the Route once a router admitted it, which is generated by OpenShift when the
+// Route does not set a host. It returns an empty host when the Route is not admitted yet.
+func GetAdmittedRouteHost(route *routev1.Route) string {
+	for _, ingress := range route.Status.Ingress {
+		for _, c := range ingress.Conditions {
+			if c.Type == routev1.RouteAdmitted && c.Status == corev1.ConditionTrue {
+				return route.Spec.Host
+			}
+		}
+	}
+	return ""
+}
+
+// CustomizeAdditionalRoute customizes the Route that exposes an additional path of spec.route. A Route has a single
+// host and path, so each additional path gets its own Route.
+func CustomizeAdditionalRoute(route *routev1.Route, ba common.BaseComponent, path RoutePath, key string, crt string, ca string, destCACert string) {
+	CustomizeRoute(route, ba, key, crt, ca, destCACert)
+	route.Spec.Host = path.Host
+	route.Spec.Path = path.Path
+	route.Spec.Port.TargetPort = intstr.FromString(path.PortName)
+}
+
 // ErrorIsNoMatchesForKind ...
 func ErrorIsNoMatchesForKind(err error, kind string, version string) bool {
 	return strings.HasPrefix(err.Error(), fmt.Sprintf("no matches for kind \"%s\" in version \"%s\"", kind, version))
@@ -673,6 +709,42 @@ func CustomizePodSpec(pts *corev1.PodTemplateSpec, ba common.BaseComponent) {
 		})
 	}
 
+	// Mutual TLS client certificate and trust bundle, set by the reconciler once they are available
+	if secretName := ba.GetStatus().GetReferences()[common.StatusReferenceClientCertSecretName]; secretName != "" {
+		appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "TLS_CLIENT_DIR", Value: "/etc/x509/client"})
+		pts.Spec.Volumes = append(pts.Spec.Volumes, corev1.Volume{
+			Name: "client-certificate",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		})
+		appContainer.VolumeMounts = append(appContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      "client-certificate",
+			MountPath: "/etc/x509/client",
+			ReadOnly:  true,
+		})
+	}
+	if bundleName := ba.GetStatus().GetReferences()[common.StatusReferenceTrustBundleName]; bundleName != "" {
+		appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: "TLS_TRUST_DIR", Value: "/etc/x509/trust"})
+		pts.Spec.Volumes = append(pts.Spec.Volumes, corev1.Volume{
+			Name: "trust-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: bundleName},
+				},
+			},
+		})
+		appContainer.VolumeMounts = append(appContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      "trust-bundle",
+			MountPath: "/etc/x509/trust",
+			ReadOnly:  true,
+		})
+	}
+
+	customizeServiceBindings(&appContainer, &pts.Spec.Volumes, ba)
+
 	// This ensures that the pods are updated if the service account is updated
 	saRV := ba.GetStatus().GetReferences()[common.StatusReferenceSAResourceVersion]
 	if saRV != "" {
@@ -846,8 +918,10 @@ func CustomizeKnativeService(ksvc *servingv1.Service, ba common.BaseComponent) {
 	}
 
 	ksvc.Spec.Template.Spec.Containers[0].Image = ba.GetStatus().GetImageReference()
-	// Knative sets its own resource constraints
-	// ksvc.Spec.Template.Spec.Containers[0].Resources = *cr.Spec.ResourceConstraints
+	ksvc.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+	if ba.GetResourceConstraints() != nil {
+		ksvc.Spec.Template.Spec.Containers[0].Resources = *ba.GetResourceConstraints()
+	}
 
 	CustomizeProbes(&ksvc.Spec.Template.Spec.Containers[0], ba)
 
@@ -890,6 +964,124 @@ func CustomizeKnativeService(ksvc *servingv1.Service, ba common.BaseComponent) {
 			ksvc.Spec.Template.Spec.Containers[0].StartupProbe.TCPSocket.Port = intstr.IntOrString{}
 		}
 	}
+
+	ksvc.Spec.Template.Spec.ContainerConcurrency = nil
+	ksvc.Spec.Template.Spec.TimeoutSeconds = nil
+	if kn := ba.GetKnative(); kn != nil {
+		ksvc.Spec.Template.Spec.ContainerConcurrency = kn.GetContainerConcurrency()
+		ksvc.Spec.Template.Spec.TimeoutSeconds = kn.GetTimeoutSeconds()
+	}
+
+	customizeKnativeAutoscaling(ksvc, ba)
+	customizeKnativeTraffic(ksvc, ba)
+}
+
+// Annotations of the Knative revision template that configure the Knative autoscaler
+const (
+	KnativeAutoscalingClassAnnotation    = "autoscaling.knative.dev/class"
+	KnativeAutoscalingMetricAnnotation   = "autoscaling.knative.dev/metric"
+	KnativeAutoscalingTargetAnnotation   = "autoscaling.knative.dev/target"
+	KnativeAutoscalingMinScaleAnnotation = "autoscaling.knative.dev/min-scale"
+	KnativeAutoscalingMaxScaleAnnotation = "autoscaling.knative.dev/max-scale"
+)
+
+// getKnativeAutoscaling returns the autoscaler class, metric and target of a Knative Service, with defaults applied.
+// The class and metric are empty when spec.knative.autoscaling is not set.
+func getKnativeAutoscaling(ba common.BaseComponent) (class string, metric string, target *int32) {
+	// The hpa class needs the HPA extension of Knative, so it is only used when spec.knative.autoscaling asks for it
+	if ba.GetKnative() == nil || ba.GetKnative().GetAutoscaling() == nil {
+		return "", "", nil
+	}
+	ka := ba.GetKnative().GetAutoscaling()
+	if ka.GetClass() != nil {
+		class = *ka.GetClass()
+	}
+	if ka.GetMetric() != nil {
+		metric = *ka.GetMetric()
+	}
+	target = ka.GetTarget()
+	if metric == "" && class == "hpa" {
+		metric = "cpu"
+	}
+	if class == "" {
+		class = "kpa"
+		if metric == "cpu" || metric == "memory" {
+			class = "hpa"
+		}
+	}
+	if as := ba.GetAutoscaling(); target == nil && metric == "cpu" && as != nil {
+		target = as.GetTargetCPUUtilizationPercentage()
+	}
+	return class, metric, target
+}
+
+// customizeKnativeAutoscaling translates the autoscaling settings into the annotations of the Knative revision template.
+// Autoscaling annotations set on the RuntimeComponent are kept as they are.
+func customizeKnativeAutoscaling(ksvc *servingv1.Service, ba common.BaseComponent) {
+	annotations := ksvc.Spec.Template.ObjectMeta.Annotations
+	for _, key := range []string{KnativeAutoscalingClassAnnotation, KnativeAutoscalingMetricAnnotation, KnativeAutoscalingTargetAnnotation,
+		KnativeAutoscalingMinScaleAnnotation, KnativeAutoscalingMaxScaleAnnotation} {
+		if _, ok := ba.GetAnnotations()[key]; !ok {
+			delete(annotations, key)
+		}
+	}
+
+	desired := map[string]string{}
+	if as := ba.GetAutoscaling(); as != nil {
+		if as.GetMinReplicas() != nil {
+			desired[KnativeAutoscalingMinScaleAnnotation] = strconv.Itoa(int(*as.GetMinReplicas()))
+		}
+		if as.GetMaxReplicas() > 0 {
+			desired[KnativeAutoscalingMaxScaleAnnotation] = strconv.Itoa(int(as.GetMaxReplicas()))
+		}
+	}
+	class, metric, target := getKnativeAutoscaling(ba)
+	if class != "" {
+		desired[KnativeAutoscalingClassAnnotation] = class + ".autoscaling.knative.dev"
+	}
+	if metric != "" {
+		desired[KnativeAutoscalingMetricAnnotation] = metric
+	}
+	if target != nil {
+		desired[KnativeAutoscalingTargetAnnotation] = strconv.Itoa(int(*target))
+	}
+	ksvc.Spec.Template.ObjectMeta.Annotations = MergeMaps(desired, annotations)
+}
+
+// Names of the status endpoints reported for a Knative service
+const (
+	// KnativeEndpointName is the name of the status endpoint reported for the URL of the Knative service
+	KnativeEndpointName = "Knative"
+	// KnativeTagEndpointPrefix is the prefix of the status endpoints reported for tagged Knative traffic targets
+	KnativeTagEndpointPrefix = "KnativeTag-"
+)
+
+// customizeKnativeTraffic sets the traffic targets of a Knative Service, with the same defaults as the Knative webhook
+// so that the Knative Service is not updated on every reconcile
+func customizeKnativeTraffic(ksvc *servingv1.Service, ba common.BaseComponent) {
+	if ba.GetKnative() == nil || len(ba.GetKnative().GetTraffic()) == 0 {
+		latestRevision, percent := true, int64(100)
+		ksvc.Spec.Traffic = []servingv1.TrafficTarget{{LatestRevision: &latestRevision, Percent: &percent}}
+		return
+	}
+
+	ksvc.Spec.Traffic = nil
+	for _, t := range ba.GetKnative().GetTraffic() {
+		latestRevision := t.GetRevisionName() == ""
+		if t.GetLatestRevision() != nil {
+			latestRevision = *t.GetLatestRevision()
+		}
+		var percent int64
+		if t.GetPercent() != nil {
+			percent = *t.GetPercent()
+		}
+		ksvc.Spec.Traffic = append(ksvc.Spec.Traffic, servingv1.TrafficTarget{
+			Tag:            t.GetTag(),
+			RevisionName:   t.GetRevisionName(),
+			LatestRevision: &latestRevision,
+			Percent:        &percent,
+		})
+	}
 }
 
 // CustomizeHPA ...
@@ -912,198 +1104,871 @@ func CustomizeHPA(hpa *autoscalingv1.HorizontalPodAutoscaler, ba common.BaseComp
 	}
 }
 
-// Validate if the BaseComponent is valid
-func Validate(ba common.BaseComponent) (bool, error) {
-	// Storage validation
-	ss := ba.GetStatefulSet()
-	if ss != nil && ss.GetStorage() != nil {
-		if ss.GetStorage().GetVolumeClaimTemplate() == nil {
-			if ss.GetStorage().GetSize() == "" {
-				return false, fmt.Errorf("validation failed: " + requiredFieldMessage("spec.statefulSet.storage.size"))
-			}
-			if _, err := resource.ParseQuantity(ss.GetStorage().GetSize()); err != nil {
-				return false, fmt.Errorf("validation failed: cannot parse '%v': %v", ss.GetStorage().GetSize(), err)
-			}
-		}
+// CustomizeHPAV2 customizes an autoscaling/v2 HorizontalPodAutoscaler
+func CustomizeHPAV2(hpa *autoscalingv2.HorizontalPodAutoscaler, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	hpa.Labels = ba.GetLabels()
+	hpa.Annotations = MergeMaps(hpa.Annotations, ba.GetAnnotations())
+
+	as := ba.GetAutoscaling()
+	hpa.Spec.MaxReplicas = as.GetMaxReplicas()
+	hpa.Spec.MinReplicas = as.GetMinReplicas()
+
+	hpa.Spec.Metrics = nil
+	if as.GetTargetCPUUtilizationPercentage() != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: as.GetTargetCPUUtilizationPercentage(),
+				},
+			},
+		})
 	}
+	hpa.Spec.Metrics = append(hpa.Spec.Metrics, as.GetMetrics()...)
+	hpa.Spec.Behavior = as.GetBehavior()
 
-	return true, nil
-}
+	hpa.Spec.ScaleTargetRef.Name = obj.GetName()
+	hpa.Spec.ScaleTargetRef.APIVersion = "apps/v1"
 
-func createValidationError(msg string) error {
-	return fmt.Errorf("validation failed: " + msg)
+	if ba.GetStatefulSet() != nil {
+		hpa.Spec.ScaleTargetRef.Kind = "StatefulSet"
+	} else {
+		hpa.Spec.ScaleTargetRef.Kind = "Deployment"
+	}
 }
 
-func requiredFieldMessage(fieldPaths ...string) string {
-	return "must set the field(s): " + strings.Join(fieldPaths, ", ")
-}
+// ScaledObjectGVK is the GroupVersionKind of the KEDA ScaledObject
+var ScaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
 
-// CustomizeServiceMonitor ...
-func CustomizeServiceMonitor(sm *prometheusv1.ServiceMonitor, ba common.BaseComponent) {
+// CustomizeScaledObject customizes a KEDA ScaledObject. KEDA creates and manages its own HorizontalPodAutoscaler for it.
+func CustomizeScaledObject(so *unstructured.Unstructured, ba common.BaseComponent) error {
 	obj := ba.(metav1.Object)
-	sm.Labels = ba.GetLabels()
-	sm.Annotations = MergeMaps(sm.Annotations, ba.GetAnnotations())
+	so.SetGroupVersionKind(ScaledObjectGVK)
+	so.SetLabels(ba.GetLabels())
+	so.SetAnnotations(MergeMaps(so.GetAnnotations(), ba.GetAnnotations()))
 
-	sm.Spec.Selector = metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			"app.kubernetes.io/instance":                obj.GetName(),
-			"monitor." + ba.GetGroupName() + "/enabled": "true",
-		},
+	kind := "Deployment"
+	if ba.GetStatefulSet() != nil {
+		kind = "StatefulSet"
 	}
-	if len(sm.Spec.Endpoints) == 0 {
-		sm.Spec.Endpoints = append(sm.Spec.Endpoints, prometheusv1.Endpoint{})
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"name":       obj.GetName(),
+		},
 	}
-	sm.Spec.Endpoints[0].Port = ""
-	sm.Spec.Endpoints[0].TargetPort = nil
-	sm.Spec.Endpoints[0].TLSConfig = nil
-	sm.Spec.Endpoints[0].Scheme = ""
 
-	if len(ba.GetMonitoring().GetEndpoints()) > 0 {
-		port := ba.GetMonitoring().GetEndpoints()[0].Port
-		targetPort := ba.GetMonitoring().GetEndpoints()[0].TargetPort
-		if port != "" {
-			sm.Spec.Endpoints[0].Port = port
-		}
-		if targetPort != nil {
-			sm.Spec.Endpoints[0].TargetPort = targetPort
-		}
-		if port != "" && targetPort != nil {
-			sm.Spec.Endpoints[0].TargetPort = nil
-		}
+	as := ba.GetAutoscaling()
+	keda := as.GetKeda()
+	spec["maxReplicaCount"] = int64(as.GetMaxReplicas())
+	if as.GetMinReplicas() != nil {
+		spec["minReplicaCount"] = int64(*as.GetMinReplicas())
 	}
-	if sm.Spec.Endpoints[0].Port == "" && sm.Spec.Endpoints[0].TargetPort == nil {
-		if ba.GetService().GetPortName() != "" {
-			sm.Spec.Endpoints[0].Port = ba.GetService().GetPortName()
-		} else {
-			sm.Spec.Endpoints[0].Port = strconv.Itoa(int(ba.GetService().GetPort())) + "-tcp"
-		}
+	if keda.GetPollingInterval() != nil {
+		spec["pollingInterval"] = int64(*keda.GetPollingInterval())
 	}
-	if len(ba.GetMonitoring().GetLabels()) > 0 {
-		for k, v := range ba.GetMonitoring().GetLabels() {
-			sm.Labels[k] = v
-		}
+	if keda.GetCooldownPeriod() != nil {
+		spec["cooldownPeriod"] = int64(*keda.GetCooldownPeriod())
 	}
-
-	if len(ba.GetMonitoring().GetEndpoints()) > 0 {
-		endpoints := ba.GetMonitoring().GetEndpoints()
-		if endpoints[0].Scheme != "" {
-			sm.Spec.Endpoints[0].Scheme = endpoints[0].Scheme
-		}
-		if endpoints[0].Interval != "" {
-			sm.Spec.Endpoints[0].Interval = endpoints[0].Interval
-		}
-		if endpoints[0].Path != "" {
-			sm.Spec.Endpoints[0].Path = endpoints[0].Path
+	if as.GetBehavior() != nil {
+		behavior, err := runtime.DefaultUnstructuredConverter.ToUnstructured(as.GetBehavior())
+		if err != nil {
+			return err
 		}
-
-		if endpoints[0].TLSConfig != nil {
-			sm.Spec.Endpoints[0].TLSConfig = endpoints[0].TLSConfig
+		spec["advanced"] = map[string]interface{}{
+			"horizontalPodAutoscalerConfig": map[string]interface{}{
+				"behavior": behavior,
+			},
 		}
+	}
 
-		if endpoints[0].BasicAuth != nil {
-			sm.Spec.Endpoints[0].BasicAuth = endpoints[0].BasicAuth
+	triggers := []interface{}{}
+	if as.GetTargetCPUUtilizationPercentage() != nil {
+		triggers = append(triggers, map[string]interface{}{
+			"type":       "cpu",
+			"metricType": string(autoscalingv2.UtilizationMetricType),
+			"metadata": map[string]interface{}{
+				"value": strconv.Itoa(int(*as.GetTargetCPUUtilizationPercentage())),
+			},
+		})
+	}
+	for _, t := range keda.GetTriggers() {
+		trigger := map[string]interface{}{
+			"type": t.GetType(),
 		}
-
-		if endpoints[0].Params != nil {
-			sm.Spec.Endpoints[0].Params = endpoints[0].Params
+		if t.GetName() != "" {
+			trigger["name"] = t.GetName()
 		}
-		if endpoints[0].ScrapeTimeout != "" {
-			sm.Spec.Endpoints[0].ScrapeTimeout = endpoints[0].ScrapeTimeout
+		if t.GetMetricType() != "" {
+			trigger["metricType"] = string(t.GetMetricType())
 		}
-		if endpoints[0].BearerTokenFile != "" {
-			sm.Spec.Endpoints[0].BearerTokenFile = endpoints[0].BearerTokenFile
+		metadata := map[string]interface{}{}
+		for k, v := range t.GetMetadata() {
+			metadata[k] = v
 		}
-		sm.Spec.Endpoints[0].BearerTokenSecret = endpoints[0].BearerTokenSecret
-		sm.Spec.Endpoints[0].ProxyURL = endpoints[0].ProxyURL
-		sm.Spec.Endpoints[0].RelabelConfigs = endpoints[0].RelabelConfigs
-		sm.Spec.Endpoints[0].MetricRelabelConfigs = endpoints[0].MetricRelabelConfigs
-		sm.Spec.Endpoints[0].HonorTimestamps = endpoints[0].HonorTimestamps
-		sm.Spec.Endpoints[0].HonorLabels = endpoints[0].HonorLabels
-	}
-	if ba.GetManageTLS() == nil || *ba.GetManageTLS() {
-		if len(ba.GetMonitoring().GetEndpoints()) == 0 || ba.GetMonitoring().GetEndpoints()[0].TLSConfig == nil {
-			sm.Spec.Endpoints[0].Scheme = "HTTPS"
-			if sm.Spec.Endpoints[0].TLSConfig == nil {
-				sm.Spec.Endpoints[0].TLSConfig = &prometheusv1.TLSConfig{}
+		trigger["metadata"] = metadata
+		if ref := t.GetAuthenticationRef(); ref != nil {
+			authRef := map[string]interface{}{"name": ref.GetName()}
+			if ref.GetKind() != "" {
+				authRef["kind"] = ref.GetKind()
 			}
-			sm.Spec.Endpoints[0].TLSConfig.CA = prometheusv1.SecretOrConfigMap{}
-			sm.Spec.Endpoints[0].TLSConfig.CA.Secret = &corev1.SecretKeySelector{}
-			sm.Spec.Endpoints[0].TLSConfig.CA.Secret.Name = ba.GetStatus().GetReferences()[common.StatusReferenceCertSecretName]
-			sm.Spec.Endpoints[0].TLSConfig.CA.Secret.Key = "tls.crt"
-			sm.Spec.Endpoints[0].TLSConfig.ServerName = obj.GetName() + "." + obj.GetNamespace() + ".svc"
+			trigger["authenticationRef"] = authRef
 		}
-
+		triggers = append(triggers, trigger)
 	}
+	spec["triggers"] = triggers
 
+	return unstructured.SetNestedMap(so.Object, spec, "spec")
 }
 
-// GetCondition ...
-func GetCondition(conditionType appstacksv1beta2.StatusConditionType, status *appstacksv1beta2.RuntimeComponentStatus) *appstacksv1beta2.StatusCondition {
-	for i := range status.Conditions {
-		if status.Conditions[i].Type == conditionType {
-			return &status.Conditions[i]
-		}
-	}
+// VerticalPodAutoscalerGVK is the GroupVersionKind of the VerticalPodAutoscaler
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
 
-	return nil
-}
+// CustomizeVerticalPodAutoscaler customizes a VerticalPodAutoscaler for the application container. Sidecar containers are not scaled.
+func CustomizeVerticalPodAutoscaler(vpa *unstructured.Unstructured, ba common.BaseComponent) error {
+	obj := ba.(metav1.Object)
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	vpa.SetLabels(ba.GetLabels())
+	vpa.SetAnnotations(MergeMaps(vpa.GetAnnotations(), ba.GetAnnotations()))
 
-// SetCondition ...
-func SetCondition(condition appstacksv1beta2.StatusCondition, status *appstacksv1beta2.RuntimeComponentStatus) {
-	for i := range status.Conditions {
-		if status.Conditions[i].Type == condition.Type {
-			status.Conditions[i] = condition
-			return
-		}
+	kind := "Deployment"
+	if ba.GetStatefulSet() != nil {
+		kind = "StatefulSet"
 	}
 
-	status.Conditions = append(status.Conditions, condition)
-}
-
-// GetWatchNamespaces returns a slice of namespaces the operator should watch based on WATCH_NAMESPSCE value
-// WATCH_NAMESPSCE value could be empty for watching the whole cluster or a comma-separated list of namespaces
-func GetWatchNamespaces() ([]string, error) {
-	watchNamespace, err := GetWatchNamespace()
-	if err != nil {
-		return nil, err
+	vs := ba.GetVerticalScaling()
+	controlledResources := []interface{}{}
+	for _, name := range vs.GetControlledResources() {
+		controlledResources = append(controlledResources, string(name))
 	}
-
-	var watchNamespaces []string
-	for _, ns := range strings.Split(watchNamespace, ",") {
-		watchNamespaces = append(watchNamespaces, strings.TrimSpace(ns))
+	appPolicy := map[string]interface{}{
+		"containerName":       "app",
+		"controlledResources": controlledResources,
+	}
+	if len(vs.GetMinAllowed()) > 0 {
+		appPolicy["minAllowed"] = resourceListToUnstructured(vs.GetMinAllowed())
+	}
+	if len(vs.GetMaxAllowed()) > 0 {
+		appPolicy["maxAllowed"] = resourceListToUnstructured(vs.GetMaxAllowed())
 	}
 
-	return watchNamespaces, nil
+	spec := map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"name":       obj.GetName(),
+		},
+		"updatePolicy": map[string]interface{}{
+			"updateMode": vs.GetUpdateMode(),
+		},
+		"resourcePolicy": map[string]interface{}{
+			"containerPolicies": []interface{}{
+				appPolicy,
+				map[string]interface{}{
+					"containerName": "*",
+					"mode":          "Off",
+				},
+			},
+		},
+	}
+	return unstructured.SetNestedMap(vpa.Object, spec, "spec")
 }
 
-// MergeMaps returns a map containing the union of al the key-value pairs from the input maps. The order of the maps passed into the
-// func, defines the importance. e.g. if (keyA, value1) is in map1, and (keyA, value2) is in map2, mergeMaps(map1, map2) would contain (keyA, value2).
-// If the input map is nil, it is treated as empty map.
-func MergeMaps(maps ...map[string]string) map[string]string {
-	dest := make(map[string]string)
-
-	for i := range maps {
-		for key, value := range maps[i] {
-			dest[key] = value
+// GetResourceRecommendations returns the resources recommended by a VerticalPodAutoscaler for the application container
+func GetResourceRecommendations(vpa *unstructured.Unstructured) (target, lowerBound, upperBound corev1.ResourceList) {
+	recommendations, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	for _, r := range recommendations {
+		recommendation, ok := r.(map[string]interface{})
+		if !ok || recommendation["containerName"] != "app" {
+			continue
 		}
+		return unstructuredToResourceList(recommendation["target"]), unstructuredToResourceList(recommendation["lowerBound"]), unstructuredToResourceList(recommendation["upperBound"])
 	}
-
-	return dest
+	return nil, nil, nil
 }
 
-// BuildServiceBindingSecretName returns secret name of a consumable service
-func BuildServiceBindingSecretName(name, namespace string) string {
-	return fmt.Sprintf("%s-%s", namespace, name)
+func resourceListToUnstructured(list corev1.ResourceList) map[string]interface{} {
+	m := map[string]interface{}{}
+	for name, quantity := range list {
+		m[string(name)] = quantity.String()
+	}
+	return m
 }
 
-// ContainsString returns true if `s` is in the slice. Otherwise, returns false
-func ContainsString(slice []string, s string) bool {
-	for _, str := range slice {
-		if str == s {
-			return true
+func unstructuredToResourceList(value interface{}) corev1.ResourceList {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	list := corev1.ResourceList{}
+	for name, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if quantity, err := resource.ParseQuantity(s); err == nil {
+			list[corev1.ResourceName(name)] = quantity
 		}
 	}
-	return false
+	return list
+}
+
+// CustomizePodDisruptionBudget ...
+func CustomizePodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	pdb.Labels = ba.GetLabels()
+	pdb.Annotations = MergeMaps(pdb.Annotations, ba.GetAnnotations())
+
+	// The canary pods have their own instance label, but keep the component name label of the application
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			common.GetComponentNameLabel(ba): obj.GetName(),
+		},
+	}
+
+	pdb.Spec.MinAvailable = nil
+	pdb.Spec.MaxUnavailable = nil
+	db := ba.GetDisruptionBudget()
+	if db != nil && db.GetMinAvailable() != nil {
+		pdb.Spec.MinAvailable = db.GetMinAvailable()
+	} else if db != nil && db.GetMaxUnavailable() != nil {
+		pdb.Spec.MaxUnavailable = db.GetMaxUnavailable()
+	} else {
+		maxUnavailable := intstr.FromInt(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+}
+
+// Validate if the BaseComponent is valid
+func Validate(ba common.BaseComponent) (bool, error) {
+	// Storage validation
+	ss := ba.GetStatefulSet()
+	if ss != nil && ss.GetStorage() != nil {
+		if ss.GetStorage().GetVolumeClaimTemplate() == nil {
+			if ss.GetStorage().GetSize() == "" {
+				return false, fmt.Errorf("validation failed: " + requiredFieldMessage("spec.statefulSet.storage.size"))
+			}
+			if _, err := resource.ParseQuantity(ss.GetStorage().GetSize()); err != nil {
+				return false, fmt.Errorf("validation failed: cannot parse '%v': %v", ss.GetStorage().GetSize(), err)
+			}
+		}
+	}
+
+	if err := validateAutoscaling(ba); err != nil {
+		return false, err
+	}
+	if err := validateService(ba); err != nil {
+		return false, err
+	}
+	if err := validateRoute(ba); err != nil {
+		return false, err
+	}
+	if err := validateVerticalScaling(ba); err != nil {
+		return false, err
+	}
+	if err := validateRollout(ba); err != nil {
+		return false, err
+	}
+	if err := validateKnative(ba); err != nil {
+		return false, err
+	}
+	if err := validateBindings(ba); err != nil {
+		return false, err
+	}
+
+	db := ba.GetDisruptionBudget()
+	if db != nil && db.GetMinAvailable() != nil && db.GetMaxUnavailable() != nil {
+		return false, createValidationError("spec.disruptionBudget.minAvailable and spec.disruptionBudget.maxUnavailable cannot be set together")
+	}
+
+	return true, nil
+}
+
+// ValidateAdmission validates the component like Validate, and also rejects configurations that do not work as
+// intended but that existing components might already use. These checks only run in the admission webhook, so that
+// the existing components keep being reconciled.
+func ValidateAdmission(ba common.BaseComponent) (bool, error) {
+	if ok, err := Validate(ba); !ok {
+		return ok, err
+	}
+	if err := validateRouteTermination(ba); err != nil {
+		return false, err
+	}
+	if err := validateNodePorts(ba); err != nil {
+		return false, err
+	}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() && ba.GetStatefulSet() != nil {
+		return false, createValidationError("spec.createKnativeService and spec.statefulSet cannot be set together")
+	}
+	return true, nil
+}
+
+func validateAutoscaling(ba common.BaseComponent) error {
+	as := ba.GetAutoscaling()
+	if as == nil {
+		return nil
+	}
+	if as.GetMaxReplicas() == 0 {
+		return createValidationError(requiredFieldMessage("spec.autoscaling.maxReplicas"))
+	}
+	if as.GetMinReplicas() != nil && as.GetMaxReplicas() < *as.GetMinReplicas() {
+		return createValidationError(fmt.Sprintf("spec.autoscaling.maxReplicas (%d) must be greater than or equal to spec.autoscaling.minReplicas (%d)", as.GetMaxReplicas(), *as.GetMinReplicas()))
+	}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		if as.GetKeda() != nil || len(as.GetMetrics()) > 0 || as.GetBehavior() != nil {
+			return createValidationError("spec.autoscaling.keda, spec.autoscaling.metrics and spec.autoscaling.behavior cannot be used with spec.createKnativeService, use spec.knative.autoscaling instead")
+		}
+		return nil
+	}
+	keda := as.GetKeda()
+	if keda == nil {
+		if as.GetMinReplicas() != nil && *as.GetMinReplicas() < 1 {
+			return createValidationError("spec.autoscaling.minReplicas can only be 0 when spec.autoscaling.keda or spec.createKnativeService is set")
+		}
+		return nil
+	}
+	if len(as.GetMetrics()) > 0 {
+		return createValidationError("spec.autoscaling.metrics cannot be used with spec.autoscaling.keda, use spec.autoscaling.keda.triggers instead")
+	}
+	if len(keda.GetTriggers()) == 0 && as.GetTargetCPUUtilizationPercentage() == nil {
+		return createValidationError(requiredFieldMessage("spec.autoscaling.keda.triggers"))
+	}
+	for i, t := range keda.GetTriggers() {
+		if t.GetType() == "" {
+			return createValidationError(requiredFieldMessage(fmt.Sprintf("spec.autoscaling.keda.triggers[%d].type", i)))
+		}
+	}
+	return nil
+}
+
+func validateKnative(ba common.BaseComponent) error {
+	kn := ba.GetKnative()
+	if ba.GetCreateKnativeService() == nil || !*ba.GetCreateKnativeService() {
+		if kn != nil {
+			return createValidationError("spec.knative can only be set when spec.createKnativeService is true")
+		}
+		return nil
+	}
+	if err := validateKnativeAutoscaling(ba); err != nil {
+		return err
+	}
+	if kn == nil || len(kn.GetTraffic()) == 0 {
+		return nil
+	}
+
+	var total int64
+	tags := map[string]bool{}
+	for i, t := range kn.GetTraffic() {
+		latestRevision := t.GetLatestRevision() != nil && *t.GetLatestRevision()
+		if latestRevision == (t.GetRevisionName() != "") {
+			return createValidationError(fmt.Sprintf("spec.knative.traffic[%d] must set exactly one of revisionName or latestRevision: true", i))
+		}
+		if t.GetTag() != "" {
+			if tags[t.GetTag()] {
+				return createValidationError(fmt.Sprintf("spec.knative.traffic[%d].tag %s is used more than once", i, t.GetTag()))
+			}
+			tags[t.GetTag()] = true
+		}
+		if t.GetPercent() != nil {
+			total += *t.GetPercent()
+		}
+	}
+	if total != 100 {
+		return createValidationError(fmt.Sprintf("the percent values of spec.knative.traffic must add up to 100, but add up to %d", total))
+	}
+	return nil
+}
+
+func validateBindings(ba common.BaseComponent) error {
+	bindings := ba.GetBindings()
+	if len(bindings) == 0 {
+		return nil
+	}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return createValidationError("spec.bindings cannot be used with spec.createKnativeService")
+	}
+	for i, b := range bindings {
+		if b.GetName() == "" {
+			return createValidationError(requiredFieldMessage(fmt.Sprintf("spec.bindings[%d].name", i)))
+		}
+		// The name of the binding is part of the name of its volume
+		if errs := validation.IsDNS1123Label("binding-" + b.GetName()); len(errs) > 0 {
+			return createValidationError(fmt.Sprintf("spec.bindings[%d].name %s is not valid: %s", i, b.GetName(), strings.Join(errs, ", ")))
+		}
+		if b.GetService().GetName() == "" {
+			return createValidationError(requiredFieldMessage(fmt.Sprintf("spec.bindings[%d].service.name", i)))
+		}
+		if apiVersion := b.GetService().GetAPIVersion(); apiVersion != "" {
+			if _, err := schema.ParseGroupVersion(apiVersion); err != nil {
+				return createValidationError(fmt.Sprintf("spec.bindings[%d].service.apiVersion %s is not valid: %v", i, apiVersion, err))
+			}
+		}
+		for j, e := range b.GetEnv() {
+			if e.GetName() == "" || e.GetKey() == "" {
+				return createValidationError(requiredFieldMessage(fmt.Sprintf("spec.bindings[%d].env[%d].name", i, j), fmt.Sprintf("spec.bindings[%d].env[%d].key", i, j)))
+			}
+		}
+	}
+	return nil
+}
+
+func validateKnativeAutoscaling(ba common.BaseComponent) error {
+	class, metric, _ := getKnativeAutoscaling(ba)
+	if class == "" {
+		return nil
+	}
+	if class == "kpa" && (metric == "cpu" || metric == "memory") {
+		return createValidationError(fmt.Sprintf("spec.knative.autoscaling.metric %s requires the hpa class", metric))
+	}
+	if class == "hpa" && metric != "cpu" && metric != "memory" {
+		return createValidationError(fmt.Sprintf("spec.knative.autoscaling.metric %s requires the kpa class", metric))
+	}
+	as := ba.GetAutoscaling()
+	if as != nil && as.GetTargetCPUUtilizationPercentage() != nil && metric != "cpu" {
+		return createValidationError("spec.autoscaling.targetCPUUtilizationPercentage can only be used with the cpu metric of the Knative autoscaler")
+	}
+	if class == "hpa" && as != nil && as.GetMinReplicas() != nil && *as.GetMinReplicas() < 1 {
+		return createValidationError("the hpa class of the Knative autoscaler cannot scale to zero, spec.autoscaling.minReplicas must be 1 or more")
+	}
+	return nil
+}
+
+func validateRollout(ba common.BaseComponent) error {
+	ro := ba.GetRollout()
+	if ro == nil {
+		return nil
+	}
+	if ba.GetStatefulSet() != nil {
+		return createValidationError("spec.rollout cannot be set together with spec.statefulSet")
+	}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return createValidationError("spec.rollout cannot be set together with spec.createKnativeService")
+	}
+	if ro.GetStrategy() == RolloutStrategyCanary && len(ro.GetSteps()) == 0 {
+		return createValidationError(requiredFieldMessage("spec.rollout.steps"))
+	}
+	return nil
+}
+
+func validateVerticalScaling(ba common.BaseComponent) error {
+	vs := ba.GetVerticalScaling()
+	if vs == nil {
+		return nil
+	}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return createValidationError("spec.verticalScaling cannot be set together with spec.createKnativeService")
+	}
+	if vs.GetUpdateMode() == "Off" || ba.GetAutoscaling() == nil {
+		return nil
+	}
+	// The two autoscalers would keep reacting to each other's changes to the same resource
+	scaled := autoscaledResources(ba.GetAutoscaling())
+	for _, name := range vs.GetControlledResources() {
+		if scaled[name] {
+			return createValidationError(fmt.Sprintf("spec.verticalScaling.updateMode %s cannot control %s while spec.autoscaling scales on %s utilization, set spec.verticalScaling.updateMode to Off or remove %s from spec.verticalScaling.controlledResources", vs.GetUpdateMode(), name, name, name))
+		}
+	}
+	return nil
+}
+
+// autoscaledResources returns the resources whose utilization the horizontal autoscaler scales the application on
+func autoscaledResources(as common.BaseComponentAutoscaling) map[corev1.ResourceName]bool {
+	scaled := map[corev1.ResourceName]bool{}
+	if as.GetTargetCPUUtilizationPercentage() != nil {
+		scaled[corev1.ResourceCPU] = true
+	}
+	if as.GetKeda() != nil {
+		for _, t := range as.GetKeda().GetTriggers() {
+			if t.GetType() == string(corev1.ResourceCPU) || t.GetType() == string(corev1.ResourceMemory) {
+				scaled[corev1.ResourceName(t.GetType())] = true
+			}
+		}
+		return scaled
+	}
+	// A HorizontalPodAutoscaler without metrics scales on CPU utilization
+	if len(as.GetMetrics()) == 0 {
+		scaled[corev1.ResourceCPU] = true
+	}
+	for _, m := range as.GetMetrics() {
+		if m.Resource != nil {
+			scaled[m.Resource.Name] = true
+		}
+		if m.ContainerResource != nil {
+			scaled[m.ContainerResource.Name] = true
+		}
+	}
+	return scaled
+}
+
+func validateService(ba common.BaseComponent) error {
+	svc := ba.GetService()
+	if svc == nil {
+		return nil
+	}
+
+	// Port names must be unique across the primary port and the additional ports
+	portName := svc.GetPortName()
+	if portName == "" {
+		portName = strconv.Itoa(int(svc.GetPort())) + "-tcp"
+	}
+	names := map[string]bool{portName: true}
+	for i, port := range svc.GetPorts() {
+		name := port.Name
+		if name == "" {
+			name = strconv.Itoa(int(port.Port)) + "-tcp"
+		}
+		if names[name] {
+			return createValidationError(fmt.Sprintf("duplicate service port name '%s' in spec.service.ports[%d]", name, i))
+		}
+		names[name] = true
+	}
+
+	if IsMTLSEnabled(ba) {
+		if ba.GetManageTLS() != nil && !*ba.GetManageTLS() || svc.GetCertificateSecretRef() != nil {
+			return createValidationError("spec.service.mtls requires spec.manageTLS to be enabled and spec.service.certificateSecretRef to be unset")
+		}
+		if svc.GetCertificate() != nil && svc.GetCertificate().GetIssuerRef() != nil {
+			return createValidationError("spec.service.mtls cannot be used with spec.service.certificate.issuerRef, the client certificate is issued by the CA issuer managed by the operator")
+		}
+		if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+			return createValidationError("spec.service.mtls cannot be used with spec.createKnativeService")
+		}
+	}
+
+	cert := svc.GetCertificate()
+	if cert == nil {
+		return nil
+	}
+	if svc.GetCertificateSecretRef() != nil {
+		return createValidationError("spec.service.certificate and spec.service.certificateSecretRef cannot be set together")
+	}
+	if issuerRef := cert.GetIssuerRef(); issuerRef != nil {
+		if issuerRef.GetName() == "" {
+			return createValidationError(requiredFieldMessage("spec.service.certificate.issuerRef.name"))
+		}
+		switch issuerRef.GetKind() {
+		case "", certmanagerv1.IssuerKind, certmanagerv1.ClusterIssuerKind:
+		default:
+			return createValidationError(fmt.Sprintf("unsupported value '%s' for spec.service.certificate.issuerRef.kind: must be Issuer or ClusterIssuer", issuerRef.GetKind()))
+		}
+	}
+	if cert.GetDuration() != nil && cert.GetDuration().Duration <= 0 {
+		return createValidationError("spec.service.certificate.duration must be positive")
+	}
+	if cert.GetRenewBefore() != nil {
+		if cert.GetRenewBefore().Duration <= 0 {
+			return createValidationError("spec.service.certificate.renewBefore must be positive")
+		}
+		if cert.GetDuration() != nil && cert.GetRenewBefore().Duration >= cert.GetDuration().Duration {
+			return createValidationError("spec.service.certificate.renewBefore must be less than spec.service.certificate.duration")
+		}
+	}
+	if pk := cert.GetPrivateKey(); pk != nil {
+		algorithm := certmanagerv1.PrivateKeyAlgorithm(pk.GetAlgorithm())
+		if algorithm == "" {
+			algorithm = certmanagerv1.RSAKeyAlgorithm
+		}
+		var sizes []int
+		switch algorithm {
+		case certmanagerv1.RSAKeyAlgorithm:
+			sizes = []int{0, 2048, 4096, 8192}
+		case certmanagerv1.ECDSAKeyAlgorithm:
+			sizes = []int{0, 256, 384, 521}
+		case certmanagerv1.Ed25519KeyAlgorithm:
+			return nil
+		default:
+			return createValidationError(fmt.Sprintf("unsupported value '%s' for spec.service.certificate.privateKey.algorithm: must be one of RSA, ECDSA or Ed25519", pk.GetAlgorithm()))
+		}
+		for _, size := range sizes {
+			if pk.GetSize() == size {
+				return nil
+			}
+		}
+		return createValidationError(fmt.Sprintf("unsupported value '%d' for spec.service.certificate.privateKey.size with the %s algorithm", pk.GetSize(), algorithm))
+	}
+	return nil
+}
+
+func validateRoute(ba common.BaseComponent) error {
+	rt := ba.GetRoute()
+	if rt == nil {
+		return nil
+	}
+
+	switch rt.GetPathType() {
+	case "", networkingv1.PathTypeExact, networkingv1.PathTypePrefix, networkingv1.PathTypeImplementationSpecific:
+	default:
+		return createValidationError(fmt.Sprintf("unsupported value '%s' for spec.route.pathType: must be one of Exact, Prefix or ImplementationSpecific", rt.GetPathType()))
+	}
+
+	for i, p := range rt.GetAdditionalPaths() {
+		switch p.GetPathType() {
+		case "", networkingv1.PathTypeExact, networkingv1.PathTypePrefix, networkingv1.PathTypeImplementationSpecific:
+		default:
+			return createValidationError(fmt.Sprintf("unsupported value '%s' for spec.route.additionalPaths[%d].pathType: must be one of Exact, Prefix or ImplementationSpecific", p.GetPathType(), i))
+		}
+		if ba.GetService() == nil {
+			continue
+		}
+		if _, _, found := getServicePort(ba, p.GetPort()); !found {
+			return createValidationError(fmt.Sprintf("spec.route.additionalPaths[%d].port %s does not match spec.service.port or a port of spec.service.ports", i, p.GetPort().String()))
+		}
+	}
+
+	if rt.GetGateway() != nil && ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return createValidationError("spec.route.gateway cannot be used with spec.createKnativeService, Knative exposes the application through its own ingress")
+	}
+
+	if cert := rt.GetCertificate(); cert != nil {
+		if rt.GetCertificateSecretRef() != nil {
+			return createValidationError("spec.route.certificate and spec.route.certificateSecretRef cannot be set together")
+		}
+		if cert.GetIssuerRef().GetName() == "" {
+			return createValidationError(requiredFieldMessage("spec.route.certificate.issuerRef.name"))
+		}
+		switch cert.GetIssuerRef().GetKind() {
+		case "", certmanagerv1.IssuerKind, certmanagerv1.ClusterIssuerKind:
+		default:
+			return createValidationError(fmt.Sprintf("unsupported value '%s' for spec.route.certificate.issuerRef.kind: must be Issuer or ClusterIssuer", cert.GetIssuerRef().GetKind()))
+		}
+		if cert.GetDuration() != nil && cert.GetDuration().Duration <= 0 {
+			return createValidationError("spec.route.certificate.duration must be positive")
+		}
+		if rt.GetGateway() != nil || UseTLSRoute(ba) {
+			return createValidationError("spec.route.certificate cannot be used with spec.route.gateway or passthrough termination, as the Gateway or the application terminates TLS")
+		}
+		if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+			return createValidationError("spec.route.certificate cannot be used with spec.createKnativeService, Knative exposes the application through its own ingress")
+		}
+	}
+
+	if rt.GetTermination() != nil {
+		switch *rt.GetTermination() {
+		case routev1.TLSTerminationEdge, routev1.TLSTerminationReencrypt, routev1.TLSTerminationPassthrough:
+		default:
+			return createValidationError(fmt.Sprintf("unsupported value '%s' for spec.route.termination: must be one of edge, reencrypt or passthrough", *rt.GetTermination()))
+		}
+	}
+	return nil
+}
+
+// validateRouteTermination checks that the TLS termination of the route works with the TLS configuration of the application
+func validateRouteTermination(ba common.BaseComponent) error {
+	rt := ba.GetRoute()
+	if rt == nil || rt.GetTermination() == nil {
+		return nil
+	}
+	manageTLS := ba.GetManageTLS() == nil || *ba.GetManageTLS()
+	switch *rt.GetTermination() {
+	case routev1.TLSTerminationEdge:
+		// The application serves HTTPS when the operator manages TLS, so the router cannot forward plain HTTP to it
+		if manageTLS {
+			return createValidationError("spec.route.termination cannot be edge when spec.manageTLS is enabled, use reencrypt or passthrough instead")
+		}
+	case routev1.TLSTerminationReencrypt:
+		// Re-encryption needs a destination CA, from the managed or provided service certificate, or from the destCA.crt key of the route secret
+		if !manageTLS && (ba.GetService() == nil || ba.GetService().GetCertificateSecretRef() == nil) && rt.GetCertificateSecretRef() == nil {
+			return createValidationError("spec.route.termination cannot be reencrypt when spec.manageTLS is disabled and neither spec.service.certificateSecretRef nor spec.route.certificateSecretRef is set")
+		}
+	}
+	return nil
+}
+
+// validateNodePorts checks that node ports are only set for NodePort services. The node ports of other services are ignored.
+func validateNodePorts(ba common.BaseComponent) error {
+	svc := ba.GetService()
+	if svc == nil || (svc.GetType() != nil && *svc.GetType() == corev1.ServiceTypeNodePort) {
+		return nil
+	}
+	if svc.GetNodePort() != nil {
+		return createValidationError("spec.service.nodePort can only be set when spec.service.type is NodePort")
+	}
+	for i, port := range svc.GetPorts() {
+		if port.NodePort != 0 {
+			return createValidationError(fmt.Sprintf("spec.service.ports[%d].nodePort can only be set when spec.service.type is NodePort", i))
+		}
+	}
+	return nil
+}
+
+func createValidationError(msg string) error {
+	return fmt.Errorf("%w: %s", ErrValidation, msg)
+}
+
+func requiredFieldMessage(fieldPaths ...string) string {
+	return "must set the field(s): " + strings.Join(fieldPaths, ", ")
+}
+
+// CustomizeServiceMonitor ...
+func CustomizeServiceMonitor(sm *prometheusv1.ServiceMonitor, ba common.BaseComponent) {
+	obj := ba.(metav1.Object)
+	sm.Labels = ba.GetLabels()
+	sm.Annotations = MergeMaps(sm.Annotations, ba.GetAnnotations())
+
+	sm.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app.kubernetes.io/instance":                obj.GetName(),
+			"monitor." + ba.GetGroupName() + "/enabled": "true",
+		},
+	}
+	if len(sm.Spec.Endpoints) == 0 {
+		sm.Spec.Endpoints = append(sm.Spec.Endpoints, prometheusv1.Endpoint{})
+	}
+	sm.Spec.Endpoints[0].Port = ""
+	sm.Spec.Endpoints[0].TargetPort = nil
+	sm.Spec.Endpoints[0].TLSConfig = nil
+	sm.Spec.Endpoints[0].Scheme = ""
+
+	if len(ba.GetMonitoring().GetEndpoints()) > 0 {
+		port := ba.GetMonitoring().GetEndpoints()[0].Port
+		targetPort := ba.GetMonitoring().GetEndpoints()[0].TargetPort
+		if port != "" {
+			sm.Spec.Endpoints[0].Port = port
+		}
+		if targetPort != nil {
+			sm.Spec.Endpoints[0].TargetPort = targetPort
+		}
+		if port != "" && targetPort != nil {
+			sm.Spec.Endpoints[0].TargetPort = nil
+		}
+	}
+	if sm.Spec.Endpoints[0].Port == "" && sm.Spec.Endpoints[0].TargetPort == nil {
+		if ba.GetService().GetPortName() != "" {
+			sm.Spec.Endpoints[0].Port = ba.GetService().GetPortName()
+		} else {
+			sm.Spec.Endpoints[0].Port = strconv.Itoa(int(ba.GetService().GetPort())) + "-tcp"
+		}
+	}
+	if len(ba.GetMonitoring().GetLabels()) > 0 {
+		for k, v := range ba.GetMonitoring().GetLabels() {
+			sm.Labels[k] = v
+		}
+	}
+
+	if len(ba.GetMonitoring().GetEndpoints()) > 0 {
+		endpoints := ba.GetMonitoring().GetEndpoints()
+		if endpoints[0].Scheme != "" {
+			sm.Spec.Endpoints[0].Scheme = endpoints[0].Scheme
+		}
+		if endpoints[0].Interval != "" {
+			sm.Spec.Endpoints[0].Interval = endpoints[0].Interval
+		}
+		if endpoints[0].Path != "" {
+			sm.Spec.Endpoints[0].Path = endpoints[0].Path
+		}
+
+		if endpoints[0].TLSConfig != nil {
+			sm.Spec.Endpoints[0].TLSConfig = endpoints[0].TLSConfig
+		}
+
+		if endpoints[0].BasicAuth != nil {
+			sm.Spec.Endpoints[0].BasicAuth = endpoints[0].BasicAuth
+		}
+
+		if endpoints[0].Params != nil {
+			sm.Spec.Endpoints[0].Params = endpoints[0].Params
+		}
+		if endpoints[0].ScrapeTimeout != "" {
+			sm.Spec.Endpoints[0].ScrapeTimeout = endpoints[0].ScrapeTimeout
+		}
+		if endpoints[0].BearerTokenFile != "" {
+			sm.Spec.Endpoints[0].BearerTokenFile = endpoints[0].BearerTokenFile
+		}
+		sm.Spec.Endpoints[0].BearerTokenSecret = endpoints[0].BearerTokenSecret
+		sm.Spec.Endpoints[0].ProxyURL = endpoints[0].ProxyURL
+		sm.Spec.Endpoints[0].RelabelConfigs = endpoints[0].RelabelConfigs
+		sm.Spec.Endpoints[0].MetricRelabelConfigs = endpoints[0].MetricRelabelConfigs
+		sm.Spec.Endpoints[0].HonorTimestamps = endpoints[0].HonorTimestamps
+		sm.Spec.Endpoints[0].HonorLabels = endpoints[0].HonorLabels
+	}
+	if ba.GetManageTLS() == nil || *ba.GetManageTLS() {
+		if len(ba.GetMonitoring().GetEndpoints()) == 0 || ba.GetMonitoring().GetEndpoints()[0].TLSConfig == nil {
+			sm.Spec.Endpoints[0].Scheme = "HTTPS"
+			if sm.Spec.Endpoints[0].TLSConfig == nil {
+				sm.Spec.Endpoints[0].TLSConfig = &prometheusv1.TLSConfig{}
+			}
+			sm.Spec.Endpoints[0].TLSConfig.CA = prometheusv1.SecretOrConfigMap{}
+			sm.Spec.Endpoints[0].TLSConfig.CA.Secret = &corev1.SecretKeySelector{}
+			sm.Spec.Endpoints[0].TLSConfig.CA.Secret.Name = ba.GetStatus().GetReferences()[common.StatusReferenceCertSecretName]
+			sm.Spec.Endpoints[0].TLSConfig.CA.Secret.Key = "tls.crt"
+			sm.Spec.Endpoints[0].TLSConfig.ServerName = obj.GetName() + "." + obj.GetNamespace() + ".svc"
+		}
+
+	}
+
+}
+
+// GetCondition ...
+func GetCondition(conditionType appstacksv1beta2.StatusConditionType, status *appstacksv1beta2.RuntimeComponentStatus) *appstacksv1beta2.StatusCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// SetCondition ...
+func SetCondition(condition appstacksv1beta2.StatusCondition, status *appstacksv1beta2.RuntimeComponentStatus) {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condition.Type {
+			status.Conditions[i] = condition
+			return
+		}
+	}
+
+	status.Conditions = append(status.Conditions, condition)
+}
+
+// GetWatchNamespaces returns a slice of namespaces the operator should watch based on WATCH_NAMESPSCE value
+// WATCH_NAMESPSCE value could be empty for watching the whole cluster or a comma-separated list of namespaces
+func GetWatchNamespaces() ([]string, error) {
+	watchNamespace, err := GetWatchNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	var watchNamespaces []string
+	for _, ns := range strings.Split(watchNamespace, ",") {
+		watchNamespaces = append(watchNamespaces, strings.TrimSpace(ns))
+	}
+
+	return watchNamespaces, nil
+}
+
+// MergeMaps returns a map containing the union of al the key-value pairs from the input maps. The order of the maps passed into the
+// func, defines the importance. e.g. if (keyA, value1) is in map1, and (keyA, value2) is in map2, mergeMaps(map1, map2) would contain (keyA, value2).
+// If the input map is nil, it is treated as empty map.
+func MergeMaps(maps ...map[string]string) map[string]string {
+	dest := make(map[string]string)
+
+	for i := range maps {
+		for key, value := range maps[i] {
+			dest[key] = value
+		}
+	}
+
+	return dest
+}
+
+// BuildServiceBindingSecretName returns secret name of a consumable service
+func BuildServiceBindingSecretName(name, namespace string) string {
+	return fmt.Sprintf("%s-%s", namespace, name)
+}
+
+// ContainsString returns true if `s` is in the slice. Otherwise, returns false
+func ContainsString(slice []string, s string) bool {
+	for _, str := range slice {
+		if str == s {
+			return true
+		}
+	}
+	return false
 }
 
 // AppendIfNotSubstring appends `a` to comma-separated list of strings in `s`
@@ -1195,73 +2060,362 @@ func GetAppContainer(containerList []corev1.Container) *corev1.Container {
 	return &containerList[0]
 }
 
+// RoutePath is a host and path that expose a port of the application Service
+type RoutePath struct {
+	Host       string
+	Path       string
+	PathType   networkingv1.PathType
+	PortName   string
+	PortNumber int32
+}
+
+// GetRoutePaths returns the host and path of spec.route followed by its additional paths, with the default
+// host and the Service port they refer to. Paths that refer to a port the Service does not have are skipped.
+func GetRoutePaths(ba common.BaseComponent) []RoutePath {
+	obj := ba.(metav1.Object)
+	primary := RoutePath{}
+	primary.PortName, primary.PortNumber, _ = getServicePort(ba, nil)
+
+	rt := ba.GetRoute()
+	if rt != nil {
+		primary.Host = rt.GetHost()
+		primary.Path = rt.GetPath()
+		primary.PathType = rt.GetPathType()
+	}
+	if defaultHostname := common.GetConfig().DefaultHostname; primary.Host == "" && defaultHostname != "" {
+		primary.Host = obj.GetName() + "-" + obj.GetNamespace() + "." + defaultHostname
+	}
+
+	paths := []RoutePath{primary}
+	if rt == nil {
+		return paths
+	}
+	for _, p := range rt.GetAdditionalPaths() {
+		path := RoutePath{Host: p.GetHost(), Path: p.GetPath(), PathType: p.GetPathType()}
+		if path.Host == "" {
+			path.Host = primary.Host
+		}
+		if path.PathType == "" {
+			path.PathType = primary.PathType
+		}
+		var found bool
+		if path.PortName, path.PortNumber, found = getServicePort(ba, p.GetPort()); !found {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// getServicePort returns the name and number of the Service port with the given number or name, or of the primary port when it is nil
+func getServicePort(ba common.BaseComponent, port *intstr.IntOrString) (string, int32, bool) {
+	svc := ba.GetService()
+	name := strconv.Itoa(int(svc.GetPort())) + "-tcp"
+	if svc.GetPortName() != "" {
+		name = svc.GetPortName()
+	}
+	if port == nil || (port.Type == intstr.Int && port.IntVal == svc.GetPort()) || (port.Type == intstr.String && port.StrVal == name) {
+		return name, svc.GetPort(), true
+	}
+	for _, p := range svc.GetPorts() {
+		name = strconv.Itoa(int(p.Port)) + "-tcp"
+		if p.Name != "" {
+			name = p.Name
+		}
+		if (port.Type == intstr.Int && port.IntVal == p.Port) || (port.Type == intstr.String && port.StrVal == name) {
+			return name, p.Port, true
+		}
+	}
+	return "", 0, false
+}
+
+// RequestsServiceCertificate returns whether the service certificate of the component is requested from cert-manager,
+// when cert-manager is installed on the cluster
+func RequestsServiceCertificate(ba common.BaseComponent) bool {
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return false
+	}
+	if ba.GetService() != nil && ba.GetService().GetCertificateSecretRef() != nil {
+		return false
+	}
+	if ba.GetManageTLS() != nil && !*ba.GetManageTLS() {
+		return false
+	}
+	if ba.GetService() != nil && ba.GetService().GetAnnotations() != nil {
+		if _, ok := ba.GetService().GetAnnotations()["service.beta.openshift.io/serving-cert-secret-name"]; ok {
+			return false
+		}
+		if _, ok := ba.GetService().GetAnnotations()["service.alpha.openshift.io/serving-cert-secret-name"]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// NeedsCertificateCleanup returns whether the component uses cert-manager resources that are not deleted with it: the
+// CA issuer shared by the components of the namespace, or the secret of the route certificate. The component needs a
+// finalizer to delete them.
+func NeedsCertificateCleanup(ba common.BaseComponent, prefix string) bool {
+	if RequestsServiceCertificate(ba) {
+		if _, managed := GetServiceCertificateIssuer(ba, prefix); managed {
+			return true
+		}
+	}
+	if ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService() {
+		return false
+	}
+	return ba.GetExpose() != nil && *ba.GetExpose() && ba.GetRoute() != nil && ba.GetRoute().GetCertificate() != nil
+}
+
+// GetServiceCertificateIssuer returns the issuer of the service certificate. It is the issuer of spec.service.certificate,
+// the issuer set in the operator configuration, or the CA issuer that the operator manages in the namespace with the
+// given prefix, in which case managed is true.
+func GetServiceCertificateIssuer(ba common.BaseComponent, prefix string) (ref certmanagermetav1.ObjectReference, managed bool) {
+	name, kind := "", ""
+	if ba.GetService() != nil && ba.GetService().GetCertificate() != nil && ba.GetService().GetCertificate().GetIssuerRef() != nil {
+		issuerRef := ba.GetService().GetCertificate().GetIssuerRef()
+		name, kind = issuerRef.GetName(), issuerRef.GetKind()
+	} else {
+		cfg := common.GetConfig()
+		name, kind = cfg.IssuerName, cfg.IssuerKind
+	}
+	if name == "" {
+		return certmanagermetav1.ObjectReference{Name: prefix + "-ca-issuer"}, true
+	}
+	if kind == "" {
+		kind = certmanagerv1.ClusterIssuerKind
+	}
+	return certmanagermetav1.ObjectReference{Name: name, Kind: kind, Group: certmanagerv1.SchemeGroupVersion.Group}, false
+}
+
+// CustomizeServiceCertificate customizes the cert-manager Certificate of the Service, which is issued by the given issuer
+func CustomizeServiceCertificate(cert *certmanagerv1.Certificate, ba common.BaseComponent, issuerRef certmanagermetav1.ObjectReference) error {
+	obj := ba.(metav1.Object)
+	cert.Labels = ba.GetLabels()
+
+	svcHost := obj.GetName() + "." + obj.GetNamespace() + ".svc"
+	cert.Spec.CommonName = svcHost
+	cert.Spec.DNSNames = []string{svcHost, svcHost + ".cluster.local"}
+	if ba.GetStatefulSet() != nil {
+		// Each pod of a StatefulSet has its own DNS name through the headless Service
+		headlessHost := "*." + obj.GetName() + "-headless." + obj.GetNamespace() + ".svc"
+		cert.Spec.DNSNames = append(cert.Spec.DNSNames, headlessHost, headlessHost+".cluster.local")
+	}
+	cert.Spec.IsCA = false
+	cert.Spec.IssuerRef = issuerRef
+	cert.Spec.SecretName = cert.Name
+
+	if ba.GetService() != nil && ba.GetService().GetCertificate() != nil {
+		for _, name := range ba.GetService().GetCertificate().GetDNSNames() {
+			if !ContainsString(cert.Spec.DNSNames, name) {
+				cert.Spec.DNSNames = append(cert.Spec.DNSNames, name)
+			}
+		}
+	}
+	return customizeCertificateParameters(cert, ba)
+}
+
+// GetClientCertificateName returns the name of the cert-manager Certificate, and of its secret, that the component uses as a mutual TLS client
+func GetClientCertificateName(ba common.BaseComponent) string {
+	return ba.(metav1.Object).GetName() + "-client-tls-cm"
+}
+
+// GetCASecretName returns the name of the secret with the CA certificate of the issuer managed by the operator in a namespace
+func GetCASecretName(prefix string) string {
+	return prefix + "-ca-tls"
+}
+
+// GetTrustBundleName returns the name of the ConfigMap with the CA certificate trusted by the components of the namespace
+func GetTrustBundleName(prefix string) string {
+	return prefix + "-trust-bundle"
+}
+
+// GetTrustedPeers returns the sorted names of the components of the same application as the instance that have mutual
+// TLS enabled and a client certificate. The listed components are not initialized, so their application name is
+// defaulted the same way as the name of the instance before they are compared.
+func GetTrustedPeers(instance *appstacksv1beta2.RuntimeComponent, components []appstacksv1beta2.RuntimeComponent) []string {
+	self := instance.DeepCopy()
+	self.Initialize()
+	peers := []string{}
+	for i := range components {
+		peer := components[i].DeepCopy()
+		peer.Initialize()
+		if peer.Name == self.Name || peer.DeletionTimestamp != nil || peer.GetApplicationName() != self.GetApplicationName() {
+			continue
+		}
+		if IsMTLSEnabled(peer) && peer.GetStatus().GetReferences()[common.StatusReferenceClientCertSecretName] != "" {
+			peers = append(peers, peer.Name)
+		}
+	}
+	sort.Strings(peers)
+	return peers
+}
+
+// IsMTLSEnabled returns whether mutual TLS is enabled for the component
+func IsMTLSEnabled(ba common.BaseComponent) bool {
+	return ba.GetService() != nil && ba.GetService().GetMTLS() != nil && *ba.GetService().GetMTLS()
+}
+
+// CustomizeClientCertificate customizes the cert-manager Certificate that the component uses to authenticate to its peers with mutual TLS.
+// The certificate has the same issuer, lifetime and private key parameters as the service certificate.
+func CustomizeClientCertificate(cert *certmanagerv1.Certificate, ba common.BaseComponent, issuerRef certmanagermetav1.ObjectReference) error {
+	obj := ba.(metav1.Object)
+	cert.Labels = ba.GetLabels()
+	cert.Spec.CommonName = obj.GetName()
+	cert.Spec.DNSNames = []string{obj.GetName() + "." + obj.GetNamespace() + ".svc"}
+	cert.Spec.Usages = []certmanagerv1.KeyUsage{certmanagerv1.UsageClientAuth, certmanagerv1.UsageDigitalSignature, certmanagerv1.UsageKeyEncipherment}
+	cert.Spec.IsCA = false
+	cert.Spec.IssuerRef = issuerRef
+	cert.Spec.SecretName = cert.Name
+	return customizeCertificateParameters(cert, ba)
+}
+
+// customizeCertificateParameters sets the lifetime, renewal time and private key of a certificate from spec.service.certificate and the operator configuration
+func customizeCertificateParameters(cert *certmanagerv1.Certificate, ba common.BaseComponent) error {
+	var svcCert common.BaseComponentServiceCertificate
+	if ba.GetService() != nil {
+		svcCert = ba.GetService().GetCertificate()
+	}
+
+	if svcCert != nil && svcCert.GetDuration() != nil {
+		cert.Spec.Duration = svcCert.GetDuration()
+	} else {
+		cert.Spec.Duration = &metav1.Duration{Duration: common.GetConfig().CertDuration}
+	}
+
+	cert.Spec.RenewBefore = nil
+	if svcCert != nil && svcCert.GetRenewBefore() != nil {
+		cert.Spec.RenewBefore = svcCert.GetRenewBefore()
+	} else if renewBefore := common.GetConfig().CertRenewBefore; renewBefore != 0 {
+		cert.Spec.RenewBefore = &metav1.Duration{Duration: renewBefore}
+	}
+
+	cert.Spec.PrivateKey = nil
+	if svcCert != nil && svcCert.GetPrivateKey() != nil {
+		cert.Spec.PrivateKey = &certmanagerv1.CertificatePrivateKey{
+			Algorithm: certmanagerv1.PrivateKeyAlgorithm(svcCert.GetPrivateKey().GetAlgorithm()),
+			Size:      svcCert.GetPrivateKey().GetSize(),
+		}
+	}
+	return nil
+}
+
+// GetRouteCertificateName returns the name of the cert-manager Certificate, and of its secret, requested for spec.route.certificate
+func GetRouteCertificateName(ba common.BaseComponent) string {
+	return ba.(metav1.Object).GetName() + "-route-tls-cm"
+}
+
+// GetRouteTLSSecretName returns the name of the secret with the TLS certificate of the Route or Ingress. It is the
+// secret of spec.route.certificateSecretRef, or the secret of the certificate requested for spec.route.certificate
+// once the certificate is ready.
+func GetRouteTLSSecretName(ba common.BaseComponent) string {
+	rt := ba.GetRoute()
+	if rt != nil && rt.GetCertificateSecretRef() != nil && *rt.GetCertificateSecretRef() != "" {
+		return *rt.GetCertificateSecretRef()
+	}
+	if rt != nil && rt.GetCertificate() != nil {
+		return ba.GetStatus().GetReferences()[common.StatusReferenceRouteCertSecretName]
+	}
+	return ""
+}
+
+// CustomizeRouteCertificate customizes the cert-manager Certificate requested for the hosts of the route
+func CustomizeRouteCertificate(cert *certmanagerv1.Certificate, ba common.BaseComponent) {
+	rtCert := ba.GetRoute().GetCertificate()
+	cert.Labels = ba.GetLabels()
+	cert.Annotations = MergeMaps(cert.Annotations, ba.GetAnnotations())
+
+	dnsNames := []string{}
+	for _, p := range GetRoutePaths(ba) {
+		if p.Host != "" && !ContainsString(dnsNames, p.Host) {
+			dnsNames = append(dnsNames, p.Host)
+		}
+	}
+	for _, name := range rtCert.GetDNSNames() {
+		if !ContainsString(dnsNames, name) {
+			dnsNames = append(dnsNames, name)
+		}
+	}
+	cert.Spec.DNSNames = dnsNames
+	cert.Spec.SecretName = GetRouteCertificateName(ba)
+	cert.Spec.IsCA = false
+
+	kind := rtCert.GetIssuerRef().GetKind()
+	if kind == "" {
+		kind = certmanagerv1.ClusterIssuerKind
+	}
+	cert.Spec.IssuerRef = certmanagermetav1.ObjectReference{
+		Name:  rtCert.GetIssuerRef().GetName(),
+		Kind:  kind,
+		Group: certmanagerv1.SchemeGroupVersion.Group,
+	}
+	cert.Spec.Duration = rtCert.GetDuration()
+}
+
 // CustomizeIngress customizes ingress resource
 func CustomizeIngress(ing *networkingv1.Ingress, ba common.BaseComponent) {
 	obj := ba.(metav1.Object)
 	ing.Labels = ba.GetLabels()
-	servicePort := strconv.Itoa(int(ba.GetService().GetPort())) + "-tcp"
-	host := ""
-	path := ""
-	pathType := networkingv1.PathType("")
 
 	rt := ba.GetRoute()
 	if rt != nil {
-		host = rt.GetHost()
-		path = rt.GetPath()
-		pathType = rt.GetPathType()
 		ing.Annotations = MergeMaps(ing.Annotations, ba.GetAnnotations(), rt.GetAnnotations())
+		ing.Spec.IngressClassName = rt.GetIngressClassName()
 	} else {
 		ing.Annotations = MergeMaps(ing.Annotations, ba.GetAnnotations())
+		ing.Spec.IngressClassName = nil
 	}
 
-	if ba.GetService().GetPortName() != "" {
-		servicePort = ba.GetService().GetPortName()
-	}
-
-	if host == "" && common.Config[common.OpConfigDefaultHostname] != "" {
-		host = obj.GetName() + "-" + obj.GetNamespace() + "." + common.Config[common.OpConfigDefaultHostname]
-	}
-	if host == "" {
+	paths := GetRoutePaths(ba)
+	if paths[0].Host == "" {
 		l := log.WithValues("Request.Namespace", obj.GetNamespace(), "Request.Name", obj.GetName())
 		l.Info("No Ingress hostname is provided. Ingress might not function correctly without hostname. It is recommended to set Ingress host or to provide default value through operator's config map.")
 	}
 
-	if pathType == "" {
-		pathType = networkingv1.PathTypeImplementationSpecific
-	}
-
-	ing.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: host,
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path:     path,
-							PathType: &pathType,
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: obj.GetName(),
-									Port: networkingv1.ServiceBackendPort{
-										Name: servicePort,
-									},
-								},
-							},
-						},
+	// Group the paths by host, in the order the hosts first appear
+	ing.Spec.Rules = []networkingv1.IngressRule{}
+	hosts := []string{}
+	for _, p := range paths {
+		pathType := p.PathType
+		if pathType == "" {
+			pathType = networkingv1.PathTypeImplementationSpecific
+		}
+		ingressPath := networkingv1.HTTPIngressPath{
+			Path:     p.Path,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: obj.GetName(),
+					Port: networkingv1.ServiceBackendPort{
+						Name: p.PortName,
 					},
 				},
 			},
-		},
-	}
+		}
 
-	tlsSecretName := ""
-	if rt != nil && rt.GetCertificateSecretRef() != nil && *rt.GetCertificateSecretRef() != "" {
-		tlsSecretName = *rt.GetCertificateSecretRef()
+		i := 0
+		for i < len(ing.Spec.Rules) && ing.Spec.Rules[i].Host != p.Host {
+			i++
+		}
+		if i == len(ing.Spec.Rules) {
+			ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
+				Host: p.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{},
+				},
+			})
+			if p.Host != "" {
+				hosts = append(hosts, p.Host)
+			}
+		}
+		ing.Spec.Rules[i].HTTP.Paths = append(ing.Spec.Rules[i].HTTP.Paths, ingressPath)
 	}
-	if tlsSecretName != "" && host != "" {
+
+	tlsSecretName := GetRouteTLSSecretName(ba)
+	if tlsSecretName != "" && len(hosts) > 0 {
 		ing.Spec.TLS = []networkingv1.IngressTLS{
 			{
-				Hosts:      []string{host},
+				Hosts:      hosts,
 				SecretName: tlsSecretName,
 			},
 		}
@@ -1326,6 +2480,28 @@ func GetWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// IsAffectedByConfig returns whether the resources or the status of the component depend on any of the given keys of the operator configuration
+func IsAffectedByConfig(ba common.BaseComponent, keys []string) bool {
+	isKnative := ba.GetCreateKnativeService() != nil && *ba.GetCreateKnativeService()
+	isExposed := ba.GetExpose() != nil && *ba.GetExpose()
+	for _, key := range keys {
+		switch key {
+		case common.OpConfigDefaultHostname, common.OpConfigGatewayName, common.OpConfigGatewayNamespace:
+			if isExposed {
+				return true
+			}
+		case common.OpConfigServerSideApply:
+			return true
+		default:
+			// The certificate configuration applies to the service, route and client certificates of non-Knative components
+			if !isKnative {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetOperatorNamespace returns the Namespace the operator installed in
 func GetOperatorNamespace() (string, error) {
 	var operatorNamespaceEnvVar = "OPERATOR_NAMESPACE"
@@ -1479,13 +2655,88 @@ func CustomizePodWithSVCCertificate(pts *corev1.PodTemplateSpec, ba common.BaseC
 		obj := ba.(metav1.Object)
 		secretName := ba.GetStatus().GetReferences()[common.StatusReferenceCertSecretName]
 		if secretName != "" {
-			return addSecretResourceVersionAsEnvVar(pts, obj, client, secretName, "SERVICE_CERT")
+			if err := addSecretResourceVersionAsEnvVar(pts, obj, client, secretName, "SERVICE_CERT"); err != nil {
+				return err
+			}
 		} else {
 			return errors.New("Service certifcate secret name must not be empty")
 		}
 	}
+	if secretName := ba.GetStatus().GetReferences()[common.StatusReferenceClientCertSecretName]; secretName != "" {
+		return addSecretResourceVersionAsEnvVar(pts, ba.(metav1.Object), client, secretName, "CLIENT_CERT")
+	}
+	return nil
+}
+
+// CustomizePodWithBindings adds the resource versions of the binding secrets to the application container, so that
+// the pods are updated when a binding secret changes
+func CustomizePodWithBindings(pts *corev1.PodTemplateSpec, ba common.BaseComponent, client client.Client) error {
+	for _, b := range ba.GetStatus().GetBindings() {
+		prefix := "BINDING_" + strings.ToUpper(strings.ReplaceAll(b.GetBindingName(), "-", "_"))
+		if err := addSecretResourceVersionAsEnvVar(pts, ba.(metav1.Object), client, b.GetSecretName(), prefix); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// customizeServiceBindings projects the binding secrets resolved by the reconciler into the application container,
+// in one directory per binding under $SERVICE_BINDING_ROOT, and sets the environment variables mapped from their entries
+func customizeServiceBindings(appContainer *corev1.Container, volumes *[]corev1.Volume, ba common.BaseComponent) {
+	secretNames := map[string]string{}
+	for _, b := range ba.GetStatus().GetBindings() {
+		secretNames[b.GetBindingName()] = b.GetSecretName()
+	}
+	if len(secretNames) == 0 {
+		return
+	}
+
+	// The application can choose the root directory of the bindings with its own SERVICE_BINDING_ROOT variable
+	root, found := DefaultServiceBindingRoot, false
+	for _, env := range appContainer.Env {
+		if env.Name == ServiceBindingRootEnv {
+			found = true
+			if env.Value != "" {
+				root = env.Value
+			}
+		}
+	}
+	if !found {
+		appContainer.Env = append(appContainer.Env, corev1.EnvVar{Name: ServiceBindingRootEnv, Value: root})
+	}
+
+	for _, b := range ba.GetBindings() {
+		secretName := secretNames[b.GetName()]
+		if secretName == "" {
+			continue
+		}
+		*volumes = append(*volumes, corev1.Volume{
+			Name: "binding-" + b.GetName(),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		})
+		appContainer.VolumeMounts = append(appContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      "binding-" + b.GetName(),
+			MountPath: path.Join(root, b.GetName()),
+			ReadOnly:  true,
+		})
+		for _, e := range b.GetEnv() {
+			appContainer.Env = append(appContainer.Env, corev1.EnvVar{
+				Name: e.GetName(),
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+						Key:                  e.GetKey(),
+					},
+				},
+			})
+		}
+	}
+}
+
 func addSecretResourceVersionAsEnvVar(pts *corev1.PodTemplateSpec, object metav1.Object, client client.Client, secretName string, envNamePrefix string) error {
 	secret := &corev1.Secret{}
 	err := client.Get(context.Background(), types.NamespacedName{Name: secretName, Namespace: object.GetNamespace()}, secret)
@@ -5,15 +5,24 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	appstacksv1beta2 "github.com/application-stacks/runtime-component-operator/api/v1beta2"
+	"github.com/application-stacks/runtime-component-operator/common"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	certmanagermetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	prometheusv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	cruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -364,6 +373,111 @@ func TestCustomizePodSpecAnnotations(t *testing.T) {
 
 }
 
+func TestCustomizePodSpecMTLS(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	mtls := true
+	svc := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, MTLS: &mtls}
+	runtime := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: appImage, Service: svc})
+	runtime.Status.SetReference(common.StatusReferenceCertSecretName, name+"-svc-tls-cm")
+	runtime.Status.SetReference(common.StatusReferenceClientCertSecretName, name+"-client-tls-cm")
+	runtime.Status.SetReference(common.StatusReferenceTrustBundleName, "rco-trust-bundle")
+	pts := &corev1.PodTemplateSpec{}
+	CustomizePodSpec(pts, runtime)
+
+	volumes := map[string]corev1.Volume{}
+	for _, v := range pts.Spec.Volumes {
+		volumes[v.Name] = v
+	}
+	mounts := map[string]string{}
+	for _, m := range pts.Spec.Containers[0].VolumeMounts {
+		mounts[m.Name] = m.MountPath
+	}
+
+	testMTLS := []Test{
+		{"Service certificate mount", "/etc/x509/certs", mounts["svc-certificate"]},
+		{"Client certificate mount", "/etc/x509/client", mounts["client-certificate"]},
+		{"Client certificate secret", name + "-client-tls-cm", volumes["client-certificate"].Secret.SecretName},
+		{"Trust bundle mount", "/etc/x509/trust", mounts["trust-bundle"]},
+		{"Trust bundle ConfigMap", "rco-trust-bundle", volumes["trust-bundle"].ConfigMap.Name},
+	}
+	verifyTests(testMTLS, t)
+}
+
+func TestCustomizePodSpecBindings(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	spec := appstacksv1beta2.RuntimeComponentSpec{
+		ApplicationImage: appImage,
+		Service:          service,
+		Env:              []corev1.EnvVar{{Name: ServiceBindingRootEnv, Value: "/var/bindings"}},
+		Bindings: []appstacksv1beta2.RuntimeComponentBinding{
+			{
+				Name:    "orders-db",
+				Service: appstacksv1beta2.RuntimeComponentBindingService{APIVersion: "postgres.example.com/v1", Kind: "Database", Name: "orders"},
+				Env:     []appstacksv1beta2.RuntimeComponentBindingEnv{{Name: "DB_HOST", Key: "host"}},
+			},
+			{
+				Name:    "inventory",
+				Service: appstacksv1beta2.RuntimeComponentBindingService{Name: "inventory"},
+			},
+		},
+	}
+	runtime := createRuntimeComponent(name, namespace, spec)
+	runtime.Status.SetBindings([]common.StatusBinding{
+		runtime.Status.NewStatusBinding("orders-db", "orders-binding"),
+		runtime.Status.NewStatusBinding("inventory", "inventory-expose-binding"),
+	})
+	pts := &corev1.PodTemplateSpec{}
+	CustomizePodSpec(pts, runtime)
+
+	volumes := map[string]corev1.Volume{}
+	for _, v := range pts.Spec.Volumes {
+		volumes[v.Name] = v
+	}
+	mounts := map[string]string{}
+	for _, m := range pts.Spec.Containers[0].VolumeMounts {
+		mounts[m.Name] = m.MountPath
+	}
+	env := map[string]corev1.EnvVar{}
+	rootCount := 0
+	for _, e := range pts.Spec.Containers[0].Env {
+		env[e.Name] = e
+		if e.Name == ServiceBindingRootEnv {
+			rootCount++
+		}
+	}
+
+	// Without its own SERVICE_BINDING_ROOT, the application gets the default root
+	runtime.Spec.Env = nil
+	defaultPts := &corev1.PodTemplateSpec{}
+	CustomizePodSpec(defaultPts, runtime)
+	defaultMounts := map[string]string{}
+	for _, m := range defaultPts.Spec.Containers[0].VolumeMounts {
+		defaultMounts[m.Name] = m.MountPath
+	}
+	defaultRoot := ""
+	for _, e := range defaultPts.Spec.Containers[0].Env {
+		if e.Name == ServiceBindingRootEnv {
+			defaultRoot = e.Value
+		}
+	}
+
+	testCPSB := []Test{
+		{"Binding secret volume", "orders-binding", volumes["binding-orders-db"].Secret.SecretName},
+		{"Binding mount under the application root", "/var/bindings/orders-db", mounts["binding-orders-db"]},
+		{"RuntimeComponent binding mount", "/var/bindings/inventory", mounts["binding-inventory"]},
+		{"SERVICE_BINDING_ROOT is not duplicated", 1, rootCount},
+		{"Env mapping secret", "orders-binding", env["DB_HOST"].ValueFrom.SecretKeyRef.Name},
+		{"Env mapping key", "host", env["DB_HOST"].ValueFrom.SecretKeyRef.Key},
+		{"Default SERVICE_BINDING_ROOT", DefaultServiceBindingRoot, defaultRoot},
+		{"Binding mount under the default root", "/bindings/orders-db", defaultMounts["binding-orders-db"]},
+	}
+	verifyTests(testCPSB, t)
+}
+
 func TestCustomizePodSpec(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
@@ -535,6 +649,48 @@ func TestCustomizeKnativeService(t *testing.T) {
 	runtime.Spec.Expose = &fls
 	CustomizeKnativeService(ksvc, runtime)
 	ksvcLabelFalseExpose := ksvc.Labels["serving.knative.dev/visibility"]
+	latestRevision, fullTraffic := true, int64(100)
+	ksvcDefaultTraffic := []servingv1.TrafficTarget{{LatestRevision: &latestRevision, Percent: &fullTraffic}}
+	ksvcTrafficNoSpec := ksvc.Spec.Traffic
+
+	stablePercent, previewPercent := int64(90), int64(10)
+	runtime.Spec.Knative = &appstacksv1beta2.RuntimeComponentKnative{Traffic: []appstacksv1beta2.RuntimeComponentKnativeTraffic{
+		{RevisionName: name + "-00001", Percent: &stablePercent, Tag: "stable"},
+		{LatestRevision: &latestRevision, Percent: &previewPercent, Tag: "preview"},
+		{RevisionName: name + "-00002", Tag: "old"},
+	}}
+	CustomizeKnativeService(ksvc, runtime)
+	ksvcTrafficSpec := ksvc.Spec.Traffic
+	notLatestRevision, noTraffic := false, int64(0)
+	ksvcTraffic := []servingv1.TrafficTarget{
+		{Tag: "stable", RevisionName: name + "-00001", LatestRevision: &notLatestRevision, Percent: &stablePercent},
+		{Tag: "preview", LatestRevision: &latestRevision, Percent: &previewPercent},
+		{Tag: "old", RevisionName: name + "-00002", LatestRevision: &notLatestRevision, Percent: &noTraffic},
+	}
+
+	runtime.Spec.Knative = nil
+	var concurrency, timeout int64 = 10, 60
+	var minScale, target int32 = 0, 50
+	rps := "rps"
+	runtime.Spec.Resources = &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}}
+	runtime.Spec.Autoscaling = &appstacksv1beta2.RuntimeComponentAutoScaling{MinReplicas: &minScale, MaxReplicas: 5}
+	runtime.Spec.Knative = &appstacksv1beta2.RuntimeComponentKnative{ContainerConcurrency: &concurrency, TimeoutSeconds: &timeout,
+		Autoscaling: &appstacksv1beta2.RuntimeComponentKnativeAutoscaling{Metric: &rps, Target: &target}}
+	CustomizeKnativeService(ksvc, runtime)
+	ksvcKPAAnnotations := ksvc.Spec.Template.Annotations
+	ksvcKPA := ksvc.Spec.Template.Spec
+
+	// targetCPUUtilizationPercentage alone does not switch to the hpa class, which needs the Knative HPA extension
+	runtime.Spec.Knative = nil
+	runtime.Spec.Autoscaling = autoscaling
+	CustomizeKnativeService(ksvc, runtime)
+	_, ksvcTargetCPUClass := ksvc.Spec.Template.Annotations[KnativeAutoscalingClassAnnotation]
+	_, ksvcTargetCPUTarget := ksvc.Spec.Template.Annotations[KnativeAutoscalingTargetAnnotation]
+
+	hpa := "hpa"
+	runtime.Spec.Knative = &appstacksv1beta2.RuntimeComponentKnative{Autoscaling: &appstacksv1beta2.RuntimeComponentKnativeAutoscaling{Class: &hpa}}
+	CustomizeKnativeService(ksvc, runtime)
+	ksvcHPAAnnotations := ksvc.Spec.Template.Annotations
 
 	testCKS := []Test{
 		{"ksvc container ports", 1, ksvcNumPorts},
@@ -549,6 +705,23 @@ func TestCustomizeKnativeService(t *testing.T) {
 		{"expose not set", "cluster-local", ksvcLabelNoExpose},
 		{"expose set to true", "", ksvcLabelTrueExpose},
 		{"expose set to false", "cluster-local", ksvcLabelFalseExpose},
+		{"default traffic", ksvcDefaultTraffic, ksvcTrafficNoSpec},
+		{"traffic from spec.knative", ksvcTraffic, ksvcTrafficSpec},
+		{"resources", *runtime.Spec.Resources, ksvcKPA.Containers[0].Resources},
+		{"containerConcurrency", &concurrency, ksvcKPA.ContainerConcurrency},
+		{"timeoutSeconds", &timeout, ksvcKPA.TimeoutSeconds},
+		{"kpa class", "kpa.autoscaling.knative.dev", ksvcKPAAnnotations[KnativeAutoscalingClassAnnotation]},
+		{"kpa metric", "rps", ksvcKPAAnnotations[KnativeAutoscalingMetricAnnotation]},
+		{"kpa target", "50", ksvcKPAAnnotations[KnativeAutoscalingTargetAnnotation]},
+		{"kpa min-scale", "0", ksvcKPAAnnotations[KnativeAutoscalingMinScaleAnnotation]},
+		{"kpa max-scale", "5", ksvcKPAAnnotations[KnativeAutoscalingMaxScaleAnnotation]},
+		{"no class from targetCPUUtilizationPercentage", false, ksvcTargetCPUClass},
+		{"no target from targetCPUUtilizationPercentage", false, ksvcTargetCPUTarget},
+		{"hpa class", "hpa.autoscaling.knative.dev", ksvcHPAAnnotations[KnativeAutoscalingClassAnnotation]},
+		{"hpa metric", "cpu", ksvcHPAAnnotations[KnativeAutoscalingMetricAnnotation]},
+		{"hpa target", strconv.Itoa(int(*autoscaling.TargetCPUUtilizationPercentage)), ksvcHPAAnnotations[KnativeAutoscalingTargetAnnotation]},
+		{"hpa min-scale", strconv.Itoa(int(*autoscaling.MinReplicas)), ksvcHPAAnnotations[KnativeAutoscalingMinScaleAnnotation]},
+		{"containerConcurrency removed", (*int64)(nil), ksvc.Spec.Template.Spec.ContainerConcurrency},
 	}
 	verifyTests(testCKS, t)
 }
@@ -580,6 +753,833 @@ func TestCustomizeHPA(t *testing.T) {
 	verifyTests(testCHPA, t)
 }
 
+func TestCustomizeHPAV2(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	memoryTarget := resource.MustParse("512Mi")
+	stabilizationWindow := int32(300)
+	memoryMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name:   corev1.ResourceMemory,
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &memoryTarget},
+		},
+	}
+	behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &stabilizationWindow},
+	}
+	autoscalingV2 := &appstacksv1beta2.RuntimeComponentAutoScaling{
+		TargetCPUUtilizationPercentage: &targetCPUPer,
+		MinReplicas:                    &replicas,
+		MaxReplicas:                    3,
+		Metrics:                        []autoscalingv2.MetricSpec{memoryMetric},
+		Behavior:                       behavior,
+	}
+
+	spec := appstacksv1beta2.RuntimeComponentSpec{Autoscaling: autoscalingV2}
+	hpa, runtime := &autoscalingv2.HorizontalPodAutoscaler{}, createRuntimeComponent(name, namespace, spec)
+	CustomizeHPAV2(hpa, runtime)
+	metrics, hpaBehavior := hpa.Spec.Metrics, hpa.Spec.Behavior
+
+	spec = appstacksv1beta2.RuntimeComponentSpec{Autoscaling: &appstacksv1beta2.RuntimeComponentAutoScaling{MaxReplicas: 3, Metrics: []autoscalingv2.MetricSpec{memoryMetric}}}
+	CustomizeHPAV2(hpa, createRuntimeComponent(name, namespace, spec))
+	noCPUMetrics := hpa.Spec.Metrics
+
+	testCHPA := []Test{
+		{"Max replicas", autoscalingV2.MaxReplicas, hpa.Spec.MaxReplicas},
+		{"Metrics count", 2, len(metrics)},
+		{"CPU metric name", corev1.ResourceCPU, metrics[0].Resource.Name},
+		{"CPU metric target", targetCPUPer, *metrics[0].Resource.Target.AverageUtilization},
+		{"Custom metric", memoryMetric, metrics[1]},
+		{"Behavior", behavior, hpaBehavior},
+		{"Metrics without target CPU", []autoscalingv2.MetricSpec{memoryMetric}, noCPUMetrics},
+		{"", name, hpa.Spec.ScaleTargetRef.Name},
+		{"", "Deployment", hpa.Spec.ScaleTargetRef.Kind},
+	}
+	verifyTests(testCHPA, t)
+}
+
+func TestCustomizeScaledObject(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	var minReplicas int32
+	pollingInterval, cooldownPeriod := int32(15), int32(120)
+	keda := &appstacksv1beta2.RuntimeComponentAutoScaling{
+		MinReplicas: &minReplicas,
+		MaxReplicas: 5,
+		Keda: &appstacksv1beta2.RuntimeComponentKeda{
+			PollingInterval: &pollingInterval,
+			CooldownPeriod:  &cooldownPeriod,
+			Triggers: []appstacksv1beta2.RuntimeComponentKedaTrigger{{
+				Type:              "rabbitmq",
+				Metadata:          map[string]string{"queueName": "orders", "value": "20"},
+				AuthenticationRef: &appstacksv1beta2.RuntimeComponentKedaAuthenticationRef{Name: "rabbitmq-auth"},
+			}},
+		},
+	}
+	spec := appstacksv1beta2.RuntimeComponentSpec{Autoscaling: keda, StatefulSet: &appstacksv1beta2.RuntimeComponentStatefulSet{}}
+	so := &unstructured.Unstructured{}
+	err := CustomizeScaledObject(so, createRuntimeComponent(name, namespace, spec))
+
+	minReplicaCount, _, _ := unstructured.NestedInt64(so.Object, "spec", "minReplicaCount")
+	maxReplicaCount, _, _ := unstructured.NestedInt64(so.Object, "spec", "maxReplicaCount")
+	pollingIntervalValue, _, _ := unstructured.NestedInt64(so.Object, "spec", "pollingInterval")
+	cooldownPeriodValue, _, _ := unstructured.NestedInt64(so.Object, "spec", "cooldownPeriod")
+	targetKind, _, _ := unstructured.NestedString(so.Object, "spec", "scaleTargetRef", "kind")
+	targetName, _, _ := unstructured.NestedString(so.Object, "spec", "scaleTargetRef", "name")
+	triggers, _, _ := unstructured.NestedSlice(so.Object, "spec", "triggers")
+	trigger := triggers[0].(map[string]interface{})
+
+	testCSO := []Test{
+		{"Error", nil, err},
+		{"Kind", ScaledObjectGVK, so.GroupVersionKind()},
+		{"Min replicas", int64(minReplicas), minReplicaCount},
+		{"Max replicas", int64(keda.MaxReplicas), maxReplicaCount},
+		{"Polling interval", int64(pollingInterval), pollingIntervalValue},
+		{"Cooldown period", int64(cooldownPeriod), cooldownPeriodValue},
+		{"Scale target kind", "StatefulSet", targetKind},
+		{"Scale target name", name, targetName},
+		{"Triggers", 1, len(triggers)},
+		{"Trigger type", "rabbitmq", trigger["type"]},
+		{"Trigger metadata", map[string]interface{}{"queueName": "orders", "value": "20"}, trigger["metadata"]},
+		{"Trigger authentication", map[string]interface{}{"name": "rabbitmq-auth"}, trigger["authenticationRef"]},
+	}
+	verifyTests(testCSO, t)
+}
+
+func TestCustomizeVerticalPodAutoscaler(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	minAllowed := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}
+	spec := appstacksv1beta2.RuntimeComponentSpec{VerticalScaling: &appstacksv1beta2.RuntimeComponentVerticalScaling{MinAllowed: minAllowed}}
+	vpa := &unstructured.Unstructured{}
+	err := CustomizeVerticalPodAutoscaler(vpa, createRuntimeComponent(name, namespace, spec))
+
+	updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	targetKind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+	policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+	appPolicy := policies[0].(map[string]interface{})
+
+	vpa.Object["status"] = map[string]interface{}{
+		"recommendation": map[string]interface{}{
+			"containerRecommendations": []interface{}{
+				map[string]interface{}{"containerName": "sidecar", "target": map[string]interface{}{"cpu": "10m"}},
+				map[string]interface{}{
+					"containerName": "app",
+					"target":        map[string]interface{}{"cpu": "250m", "memory": "262144k"},
+					"lowerBound":    map[string]interface{}{"cpu": "100m"},
+					"upperBound":    map[string]interface{}{"cpu": "1"},
+				},
+			},
+		},
+	}
+	target, lowerBound, upperBound := GetResourceRecommendations(vpa)
+
+	testCVPA := []Test{
+		{"Error", nil, err},
+		{"Kind", VerticalPodAutoscalerGVK, vpa.GroupVersionKind()},
+		{"Default update mode", "Off", updateMode},
+		{"Target kind", "Deployment", targetKind},
+		{"Container policies", 2, len(policies)},
+		{"Application container", "app", appPolicy["containerName"]},
+		{"Default controlled resources", []interface{}{"cpu", "memory"}, appPolicy["controlledResources"]},
+		{"Min allowed", map[string]interface{}{"cpu": "100m"}, appPolicy["minAllowed"]},
+		{"Sidecar containers", "Off", policies[1].(map[string]interface{})["mode"]},
+		{"Recommended CPU", "250m", target.Cpu().String()},
+		{"Recommended memory", "262144k", target.Memory().String()},
+		{"Lower bound", "100m", lowerBound.Cpu().String()},
+		{"Upper bound", "1", upperBound.Cpu().String()},
+	}
+	verifyTests(testCVPA, t)
+}
+
+func TestCustomizePodDisruptionBudget(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	minAvailable := intstr.FromString("50%")
+	maxUnavailable := intstr.FromInt(2)
+	defaultMaxUnavailable := intstr.FromInt(1)
+
+	pdb, runtime := &policyv1.PodDisruptionBudget{}, createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Autoscaling: autoscaling})
+	CustomizePodDisruptionBudget(pdb, runtime)
+	defaultPDB := pdb.DeepCopy()
+
+	rollout := &appstacksv1beta2.RuntimeComponentRollout{Steps: []appstacksv1beta2.RuntimeComponentRolloutStep{{Weight: 20}}}
+	canaryRuntime := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ApplicationImage: appImage, Service: service, Rollout: rollout})
+	canary := &appsv1.Deployment{}
+	CustomizeDeployment(canary, canaryRuntime)
+	CustomizePodSpec(&canary.Spec.Template, canaryRuntime)
+	CustomizeCanaryDeployment(canary, canaryRuntime, "my-image:2")
+	canarySelected, _ := metav1.LabelSelectorAsSelector(defaultPDB.Spec.Selector)
+
+	spec := appstacksv1beta2.RuntimeComponentSpec{DisruptionBudget: &appstacksv1beta2.RuntimeComponentDisruptionBudget{MinAvailable: &minAvailable}}
+	CustomizePodDisruptionBudget(pdb, createRuntimeComponent(name, namespace, spec))
+	minAvailablePDB := pdb.DeepCopy()
+
+	spec = appstacksv1beta2.RuntimeComponentSpec{DisruptionBudget: &appstacksv1beta2.RuntimeComponentDisruptionBudget{MaxUnavailable: &maxUnavailable}}
+	CustomizePodDisruptionBudget(pdb, createRuntimeComponent(name, namespace, spec))
+
+	testCPDB := []Test{
+		{"Selector", map[string]string{common.GetComponentNameLabel(runtime): name}, defaultPDB.Spec.Selector.MatchLabels},
+		{"Selects canary pods", true, canarySelected.Matches(labels.Set(canary.Spec.Template.Labels))},
+		{"Default maxUnavailable", &defaultMaxUnavailable, defaultPDB.Spec.MaxUnavailable},
+		{"Default minAvailable", (*intstr.IntOrString)(nil), defaultPDB.Spec.MinAvailable},
+		{"minAvailable", &minAvailable, minAvailablePDB.Spec.MinAvailable},
+		{"maxUnavailable cleared when minAvailable is set", (*intstr.IntOrString)(nil), minAvailablePDB.Spec.MaxUnavailable},
+		{"maxUnavailable", &maxUnavailable, pdb.Spec.MaxUnavailable},
+		{"minAvailable cleared when maxUnavailable is set", (*intstr.IntOrString)(nil), pdb.Spec.MinAvailable},
+	}
+	verifyTests(testCPDB, t)
+}
+
+func TestValidate(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	var minReplicas int32 = 3
+	nodePortType := corev1.ServiceTypeNodePort
+	edge, reencrypt := routev1.TLSTerminationEdge, routev1.TLSTerminationReencrypt
+	manageTLS := false
+
+	validSpec := appstacksv1beta2.RuntimeComponentSpec{Service: service, Autoscaling: autoscaling}
+	valid, err := Validate(createRuntimeComponent(name, namespace, validSpec))
+
+	badAutoscaling := &appstacksv1beta2.RuntimeComponentAutoScaling{MinReplicas: &minReplicas, MaxReplicas: 2}
+	_, errAutoscaling := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Autoscaling: badAutoscaling}))
+
+	badNodePort := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, NodePort: &nodePort}
+	_, errNodePort := ValidateAdmission(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: badNodePort}))
+	_, errNodePortReconcile := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: badNodePort}))
+	badPortsNodePort := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, Ports: []corev1.ServicePort{{Port: 9443, NodePort: nodePort}}}
+	_, errPortsNodePort := ValidateAdmission(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: badPortsNodePort}))
+	goodNodePort := &appstacksv1beta2.RuntimeComponentService{Type: &nodePortType, Port: 8443, NodePort: &nodePort, Ports: []corev1.ServicePort{{Port: 9443, NodePort: nodePort + 1}}}
+	_, errGoodNodePort := ValidateAdmission(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: goodNodePort}))
+
+	dupPorts := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, Ports: []corev1.ServicePort{{Port: 8443}}}
+	_, errDupPorts := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: dupPorts}))
+	dupPortNames := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, PortName: "web", Ports: []corev1.ServicePort{{Port: 9443, Name: "web"}}}
+	_, errDupPortNames := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: dupPortNames}))
+
+	edgeSpec := appstacksv1beta2.RuntimeComponentSpec{Route: &appstacksv1beta2.RuntimeComponentRoute{Termination: &edge}}
+	_, errEdge := ValidateAdmission(createRuntimeComponent(name, namespace, edgeSpec))
+	_, errEdgeReconcile := Validate(createRuntimeComponent(name, namespace, edgeSpec))
+	_, errEdgeNoTLS := ValidateAdmission(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ManageTLS: &manageTLS, Route: &appstacksv1beta2.RuntimeComponentRoute{Termination: &edge}}))
+	reencryptSpec := appstacksv1beta2.RuntimeComponentSpec{ManageTLS: &manageTLS, Route: &appstacksv1beta2.RuntimeComponentRoute{Termination: &reencrypt}}
+	_, errReencryptNoTLS := ValidateAdmission(createRuntimeComponent(name, namespace, reencryptSpec))
+	_, errReencryptReconcile := Validate(createRuntimeComponent(name, namespace, reencryptSpec))
+	routeSecret := "my-app-route-tls"
+	reencryptSpec.Route = &appstacksv1beta2.RuntimeComponentRoute{Termination: &reencrypt, CertificateSecretRef: &routeSecret}
+	_, errReencryptRouteSecret := ValidateAdmission(createRuntimeComponent(name, namespace, reencryptSpec))
+	_, errPathType := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Route: &appstacksv1beta2.RuntimeComponentRoute{PathType: "Regex"}}))
+
+	bothBudgets := &appstacksv1beta2.RuntimeComponentDisruptionBudget{MinAvailable: &intstr.IntOrString{IntVal: 1}, MaxUnavailable: &intstr.IntOrString{IntVal: 1}}
+	_, errBudget := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{DisruptionBudget: bothBudgets}))
+
+	_, errKnativeSS := ValidateAdmission(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{CreateKnativeService: &createKNS, StatefulSet: statefulSet}))
+
+	var zeroReplicas int32
+	kedaTriggers := &appstacksv1beta2.RuntimeComponentKeda{Triggers: []appstacksv1beta2.RuntimeComponentKedaTrigger{{Type: "kafka"}}}
+	_, errZeroHPA := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Autoscaling: &appstacksv1beta2.RuntimeComponentAutoScaling{MinReplicas: &zeroReplicas, MaxReplicas: 2}}))
+	_, errZeroKeda := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Autoscaling: &appstacksv1beta2.RuntimeComponentAutoScaling{MinReplicas: &zeroReplicas, MaxReplicas: 2, Keda: kedaTriggers}}))
+	_, errKedaNoTriggers := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Autoscaling: &appstacksv1beta2.RuntimeComponentAutoScaling{MaxReplicas: 2, Keda: &appstacksv1beta2.RuntimeComponentKeda{}}}))
+
+	auto := "Auto"
+	cpuOnly := []corev1.ResourceName{corev1.ResourceCPU}
+	memoryOnly := []corev1.ResourceName{corev1.ResourceMemory}
+	vpaSpec := appstacksv1beta2.RuntimeComponentSpec{Autoscaling: autoscaling, VerticalScaling: &appstacksv1beta2.RuntimeComponentVerticalScaling{}}
+	_, errVPAOff := Validate(createRuntimeComponent(name, namespace, vpaSpec))
+	vpaSpec.VerticalScaling = &appstacksv1beta2.RuntimeComponentVerticalScaling{UpdateMode: &auto, ControlledResources: cpuOnly}
+	_, errVPAAutoCPU := Validate(createRuntimeComponent(name, namespace, vpaSpec))
+	vpaSpec.VerticalScaling = &appstacksv1beta2.RuntimeComponentVerticalScaling{UpdateMode: &auto, ControlledResources: memoryOnly}
+	_, errVPAAutoMemory := Validate(createRuntimeComponent(name, namespace, vpaSpec))
+
+	_, errRolloutNoSteps := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Rollout: &appstacksv1beta2.RuntimeComponentRollout{}}))
+	blueGreenStrategy := RolloutStrategyBlueGreen
+	_, errRolloutSS := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{StatefulSet: statefulSet, Rollout: &appstacksv1beta2.RuntimeComponentRollout{Strategy: &blueGreenStrategy}}))
+
+	knativeTraffic := func(traffic ...appstacksv1beta2.RuntimeComponentKnativeTraffic) appstacksv1beta2.RuntimeComponentSpec {
+		return appstacksv1beta2.RuntimeComponentSpec{CreateKnativeService: &createKNS, Knative: &appstacksv1beta2.RuntimeComponentKnative{Traffic: traffic}}
+	}
+	latest := true
+	var fifty, hundred int64 = 50, 100
+	_, errKnativeTraffic := Validate(createRuntimeComponent(name, namespace, knativeTraffic(
+		appstacksv1beta2.RuntimeComponentKnativeTraffic{RevisionName: "my-app-00001", Percent: &fifty, Tag: "stable"},
+		appstacksv1beta2.RuntimeComponentKnativeTraffic{LatestRevision: &latest, Percent: &fifty, Tag: "preview"})))
+	_, errKnativeNoKsvc := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Knative: &appstacksv1beta2.RuntimeComponentKnative{}}))
+	_, errKnativeBothTargets := Validate(createRuntimeComponent(name, namespace, knativeTraffic(
+		appstacksv1beta2.RuntimeComponentKnativeTraffic{RevisionName: "my-app-00001", LatestRevision: &latest, Percent: &hundred})))
+	_, errKnativePercent := Validate(createRuntimeComponent(name, namespace, knativeTraffic(
+		appstacksv1beta2.RuntimeComponentKnativeTraffic{LatestRevision: &latest, Percent: &fifty})))
+	_, errKnativeDupTags := Validate(createRuntimeComponent(name, namespace, knativeTraffic(
+		appstacksv1beta2.RuntimeComponentKnativeTraffic{RevisionName: "my-app-00001", Percent: &fifty, Tag: "v1"},
+		appstacksv1beta2.RuntimeComponentKnativeTraffic{LatestRevision: &latest, Percent: &fifty, Tag: "v1"})))
+
+	knativeAutoscaling := func(as *appstacksv1beta2.RuntimeComponentAutoScaling, class, metric string) appstacksv1beta2.RuntimeComponentSpec {
+		return appstacksv1beta2.RuntimeComponentSpec{CreateKnativeService: &createKNS, Autoscaling: as,
+			Knative: &appstacksv1beta2.RuntimeComponentKnative{Autoscaling: &appstacksv1beta2.RuntimeComponentKnativeAutoscaling{Class: &class, Metric: &metric}}}
+	}
+	_, errKnativeScaleToZero := Validate(createRuntimeComponent(name, namespace, knativeAutoscaling(&appstacksv1beta2.RuntimeComponentAutoScaling{MinReplicas: &zeroReplicas, MaxReplicas: 2}, "kpa", "concurrency")))
+	_, errKnativeHPAScaleToZero := Validate(createRuntimeComponent(name, namespace, knativeAutoscaling(&appstacksv1beta2.RuntimeComponentAutoScaling{MinReplicas: &zeroReplicas, MaxReplicas: 2}, "hpa", "memory")))
+	_, errKnativeKPACPU := Validate(createRuntimeComponent(name, namespace, knativeAutoscaling(nil, "kpa", "cpu")))
+	_, errKnativeHPARPS := Validate(createRuntimeComponent(name, namespace, knativeAutoscaling(nil, "hpa", "rps")))
+	_, errKnativeTargetCPU := Validate(createRuntimeComponent(name, namespace, knativeAutoscaling(autoscaling, "kpa", "concurrency")))
+	_, errKnativeKeda := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{CreateKnativeService: &createKNS,
+		Autoscaling: &appstacksv1beta2.RuntimeComponentAutoScaling{MaxReplicas: 2, Keda: kedaTriggers}}))
+
+	_, errGatewayKnative := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{CreateKnativeService: &createKNS,
+		Route: &appstacksv1beta2.RuntimeComponentRoute{Gateway: &appstacksv1beta2.RuntimeComponentGateway{Name: "my-gateway"}}}))
+
+	unknownPort := intstr.FromInt(9090)
+	_, errAdditionalPathPort := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service,
+		Route: &appstacksv1beta2.RuntimeComponentRoute{AdditionalPaths: []appstacksv1beta2.RuntimeComponentRoutePath{{Path: "/admin", Port: &unknownPort}}}}))
+
+	routeCert := &appstacksv1beta2.RuntimeComponentRouteCertificate{IssuerRef: appstacksv1beta2.RuntimeComponentIssuerRef{Name: "letsencrypt"}}
+	routeSecret = "my-app-tls"
+	_, errCertAndSecretRef := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service,
+		Route: &appstacksv1beta2.RuntimeComponentRoute{Certificate: routeCert, CertificateSecretRef: &routeSecret}}))
+	passthrough := routev1.TLSTerminationPassthrough
+	_, errCertPassthrough := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service,
+		Route: &appstacksv1beta2.RuntimeComponentRoute{Certificate: routeCert, Termination: &passthrough}}))
+	_, errCertValid := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service,
+		Route: &appstacksv1beta2.RuntimeComponentRoute{Certificate: routeCert}}))
+
+	svcCertKey := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, Certificate: &appstacksv1beta2.RuntimeComponentServiceCertificate{
+		PrivateKey: &appstacksv1beta2.RuntimeComponentCertificatePrivateKey{Algorithm: "ECDSA", Size: 2048}}}
+	_, errSvcCertKeySize := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: svcCertKey}))
+	svcCertRenew := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, Certificate: &appstacksv1beta2.RuntimeComponentServiceCertificate{
+		Duration: &metav1.Duration{Duration: time.Hour}, RenewBefore: &metav1.Duration{Duration: 2 * time.Hour}}}
+	_, errSvcCertRenew := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: svcCertRenew}))
+
+	manageTLSDisabled, mtlsEnabled := false, true
+	_, errMTLSWithoutTLS := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ManageTLS: &manageTLSDisabled,
+		Service: &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, MTLS: &mtlsEnabled}}))
+	bindingService := appstacksv1beta2.RuntimeComponentBindingService{Name: "orders-db"}
+	_, errBindingName := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service,
+		Bindings: []appstacksv1beta2.RuntimeComponentBinding{{Name: "Orders_DB", Service: bindingService}}}))
+	_, errBindingEnv := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service,
+		Bindings: []appstacksv1beta2.RuntimeComponentBinding{{Name: "db", Service: bindingService, Env: []appstacksv1beta2.RuntimeComponentBindingEnv{{Name: "DB_HOST"}}}}}))
+	_, errBindingKnative := Validate(createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service, CreateKnativeService: &createKNS,
+		Bindings: []appstacksv1beta2.RuntimeComponentBinding{{Name: "db", Service: bindingService}}}))
+
+	testValidate := []Test{
+		{"Valid spec", true, valid},
+		{"Valid spec error", nil, err},
+		{"maxReplicas less than minReplicas", true, errAutoscaling != nil},
+		{"nodePort without NodePort type", true, errNodePort != nil},
+		{"nodePort without NodePort type is reconciled", nil, errNodePortReconcile},
+		{"ports nodePort without NodePort type", true, errPortsNodePort != nil},
+		{"nodePort with NodePort type", nil, errGoodNodePort},
+		{"Duplicate default port names", true, errDupPorts != nil},
+		{"Duplicate port names", true, errDupPortNames != nil},
+		{"Edge termination with manageTLS", true, errEdge != nil},
+		{"Edge termination with manageTLS is reconciled", nil, errEdgeReconcile},
+		{"Edge termination without manageTLS", nil, errEdgeNoTLS},
+		{"Reencrypt termination without manageTLS", true, errReencryptNoTLS != nil},
+		{"Reencrypt termination without manageTLS is reconciled", nil, errReencryptReconcile},
+		{"Reencrypt termination with the destination CA of the route secret", nil, errReencryptRouteSecret},
+		{"Invalid pathType", true, errPathType != nil},
+		{"Knative service with statefulSet", true, errKnativeSS != nil},
+		{"Knative traffic split", nil, errKnativeTraffic},
+		{"spec.knative without Knative service", true, errKnativeNoKsvc != nil},
+		{"Knative traffic with revisionName and latestRevision", true, errKnativeBothTargets != nil},
+		{"Knative traffic percent not adding up to 100", true, errKnativePercent != nil},
+		{"Knative traffic with duplicate tags", true, errKnativeDupTags != nil},
+		{"Knative autoscaling scaling to zero", nil, errKnativeScaleToZero},
+		{"Knative hpa class scaling to zero", true, errKnativeHPAScaleToZero != nil},
+		{"Knative kpa class with cpu metric", true, errKnativeKPACPU != nil},
+		{"Knative hpa class with rps metric", true, errKnativeHPARPS != nil},
+		{"targetCPUUtilizationPercentage with Knative concurrency metric", true, errKnativeTargetCPU != nil},
+		{"KEDA with Knative service", true, errKnativeKeda != nil},
+		{"Gateway with Knative service", true, errGatewayKnative != nil},
+		{"Additional path with unknown port", true, errAdditionalPathPort != nil},
+		{"Route certificate with certificateSecretRef", true, errCertAndSecretRef != nil},
+		{"Route certificate with passthrough termination", true, errCertPassthrough != nil},
+		{"Route certificate", nil, errCertValid},
+		{"Service certificate with unsupported key size", true, errSvcCertKeySize != nil},
+		{"Service certificate renewed after its expiry", true, errSvcCertRenew != nil},
+		{"Mutual TLS without managed TLS", true, errMTLSWithoutTLS != nil},
+		{"Binding name that is not a DNS label", true, errBindingName != nil},
+		{"Binding env without key", true, errBindingEnv != nil},
+		{"Bindings with Knative service", true, errBindingKnative != nil},
+		{"Both minAvailable and maxUnavailable", true, errBudget != nil},
+		{"Zero minReplicas without KEDA", true, errZeroHPA != nil},
+		{"Zero minReplicas with KEDA", nil, errZeroKeda},
+		{"KEDA without triggers", true, errKedaNoTriggers != nil},
+		{"VerticalPodAutoscaler Off with CPU autoscaling", nil, errVPAOff},
+		{"VerticalPodAutoscaler Auto on CPU with CPU autoscaling", true, errVPAAutoCPU != nil},
+		{"VerticalPodAutoscaler Auto on memory with CPU autoscaling", nil, errVPAAutoMemory},
+		{"Canary rollout without steps", true, errRolloutNoSteps != nil},
+		{"Rollout with statefulSet", true, errRolloutSS != nil},
+	}
+	verifyTests(testValidate, t)
+}
+
+func TestAdvanceRollout(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	pause := int32(60)
+	rollout := &appstacksv1beta2.RuntimeComponentRollout{
+		Steps: []appstacksv1beta2.RuntimeComponentRolloutStep{{Weight: 10, PauseSeconds: &pause}, {Weight: 50}},
+	}
+	rc := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Rollout: rollout})
+	start := metav1.Now()
+	later := metav1.NewTime(start.Add(2 * time.Minute))
+	expired := metav1.NewTime(start.Add(20 * time.Minute))
+
+	// The image of the existing Deployment becomes the stable image, so the new image is rolled out
+	rc.Status.ImageReference = "my-image:2"
+	AdvanceRollout(rc, "my-image:1", true, false, start)
+	started := *rc.Status.Rollout
+
+	AdvanceRollout(rc, "my-image:1", true, true, start)
+	paused := *rc.Status.Rollout
+
+	AdvanceRollout(rc, "my-image:1", true, true, later)
+	secondStep := *rc.Status.Rollout
+
+	AdvanceRollout(rc, "my-image:1", true, true, later)
+	promoting := *rc.Status.Rollout
+
+	AdvanceRollout(rc, "my-image:2", false, true, later)
+	stillPromoting := rc.Status.Rollout.Phase
+	AdvanceRollout(rc, "my-image:2", true, true, later)
+	completed := *rc.Status.Rollout
+
+	rc.Status.ImageReference = "my-image:3"
+	AdvanceRollout(rc, "my-image:2", true, false, start)
+	AdvanceRollout(rc, "my-image:2", true, false, expired)
+	aborted := *rc.Status.Rollout
+
+	AdvanceRollout(rc, "my-image:2", true, false, expired)
+	stillAborted := rc.Status.Rollout.Phase
+
+	rc.Status.ImageReference = "my-image:2"
+	AdvanceRollout(rc, "my-image:2", true, false, expired)
+	reverted := rc.Status.Rollout.Phase
+
+	strategy := RolloutStrategyBlueGreen
+	blueGreen := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Rollout: &appstacksv1beta2.RuntimeComponentRollout{Strategy: &strategy}})
+	blueGreen.Status.ImageReference = "my-image:2"
+	AdvanceRollout(blueGreen, "my-image:1", true, true, start)
+	blueGreenPreviewWeight := blueGreen.Status.Rollout.CanaryWeight
+	AdvanceRollout(blueGreen, "my-image:1", true, true, start)
+	blueGreenPromoted := *blueGreen.Status.Rollout
+
+	testAR := []Test{
+		{"Stable image", "my-image:1", started.StableImage},
+		{"Started phase", appstacksv1beta2.RolloutPhaseProgressing, started.Phase},
+		{"Canary image", "my-image:2", started.CanaryImage},
+		{"First step weight", int32(10), started.CanaryWeight},
+		{"Paused step", int32(0), paused.Step},
+		{"Second step", int32(1), secondStep.Step},
+		{"Second step weight", int32(50), secondStep.CanaryWeight},
+		{"Promoting phase", appstacksv1beta2.RolloutPhasePromoting, promoting.Phase},
+		{"Promoting stable image", "my-image:2", promoting.StableImage},
+		{"Promoting weight", int32(100), promoting.CanaryWeight},
+		{"Promoting until the stable Deployment is ready", appstacksv1beta2.RolloutPhasePromoting, stillPromoting},
+		{"Completed phase", appstacksv1beta2.RolloutPhaseCompleted, completed.Phase},
+		{"Completed weight", int32(0), completed.CanaryWeight},
+		{"Aborted after the progress deadline", appstacksv1beta2.RolloutPhaseAborted, aborted.Phase},
+		{"Aborted weight", int32(0), aborted.CanaryWeight},
+		{"Aborted image is not rolled out again", appstacksv1beta2.RolloutPhaseAborted, stillAborted},
+		{"Reverted to the stable image", appstacksv1beta2.RolloutPhaseCompleted, reverted},
+		{"Blue/green preview weight", int32(0), blueGreenPreviewWeight},
+		{"Blue/green promoted", appstacksv1beta2.RolloutPhasePromoting, blueGreenPromoted.Phase},
+	}
+	verifyTests(testAR, t)
+}
+
+func TestCustomizeCanary(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	spec := appstacksv1beta2.RuntimeComponentSpec{
+		ApplicationImage: appImage,
+		Service:          service,
+		Replicas:         &replicas,
+		Rollout:          &appstacksv1beta2.RuntimeComponentRollout{Steps: []appstacksv1beta2.RuntimeComponentRolloutStep{{Weight: 20}}},
+	}
+	runtime := createRuntimeComponent(name, namespace, spec)
+
+	deploy := &appsv1.Deployment{}
+	CustomizeDeployment(deploy, runtime)
+	CustomizePodSpec(&deploy.Spec.Template, runtime)
+	CustomizeCanaryDeployment(deploy, runtime, "my-image:2")
+
+	svc := &corev1.Service{}
+	CustomizeCanaryService(svc, runtime)
+
+	route := &routev1.Route{}
+	CustomizeRoute(route, runtime, "", "", "", "")
+	SplitRouteTraffic(route, runtime, 20)
+
+	ing := &networkingv1.Ingress{}
+	CustomizeCanaryIngress(ing, runtime, 20)
+
+	testCC := []Test{
+		{"Canary selector", map[string]string{"app.kubernetes.io/instance": name + "-canary"}, deploy.Spec.Selector.MatchLabels},
+		{"Canary pod label", name + "-canary", deploy.Spec.Template.Labels["app.kubernetes.io/instance"]},
+		{"Canary image", "my-image:2", deploy.Spec.Template.Spec.Containers[0].Image},
+		{"Canary replicas", int32(1), *deploy.Spec.Replicas},
+		{"Canary service selector", map[string]string{"app.kubernetes.io/instance": name + "-canary"}, svc.Spec.Selector},
+		{"Stable route weight", int32(80), *route.Spec.To.Weight},
+		{"Canary route backend", name + "-canary", route.Spec.AlternateBackends[0].Name},
+		{"Canary route weight", int32(20), *route.Spec.AlternateBackends[0].Weight},
+		{"Canary ingress annotation", "true", ing.Annotations[NginxCanaryAnnotation]},
+		{"Canary ingress weight", "20", ing.Annotations[NginxCanaryWeightAnnotation]},
+		{"Canary ingress backend", name + "-canary", ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name},
+	}
+	verifyTests(testCC, t)
+}
+
+func TestCustomizeIngress(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	adminPort := intstr.FromString("admin")
+	ingressClass := "nginx"
+	tlsSecret := "my-app-tls"
+	svc := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, Ports: []corev1.ServicePort{{Name: "admin", Port: 9443}}}
+	spec := appstacksv1beta2.RuntimeComponentSpec{Service: svc, Route: &appstacksv1beta2.RuntimeComponentRoute{
+		Host:                 "my-app.example.com",
+		Path:                 "/api",
+		PathType:             networkingv1.PathTypePrefix,
+		IngressClassName:     &ingressClass,
+		CertificateSecretRef: &tlsSecret,
+		AdditionalPaths: []appstacksv1beta2.RuntimeComponentRoutePath{
+			{Path: "/metrics-admin", Port: &adminPort},
+			{Host: "admin.example.com", Path: "/", PathType: networkingv1.PathTypeExact, Port: &adminPort},
+		},
+	}}
+	runtime := createRuntimeComponent(name, namespace, spec)
+
+	ing := &networkingv1.Ingress{}
+	CustomizeIngress(ing, runtime)
+	paths := GetRoutePaths(runtime)
+
+	route := &routev1.Route{}
+	CustomizeAdditionalRoute(route, runtime, paths[2], "", "", "", "")
+
+	// The host generated by OpenShift is only used once a router admitted the Route
+	generated := &routev1.Route{Spec: routev1.RouteSpec{Host: "my-app-runtime.apps.example.com"}}
+	pendingHost := GetAdmittedRouteHost(generated)
+	generated.Status.Ingress = []routev1.RouteIngress{{Conditions: []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: corev1.ConditionFalse}}}}
+	rejectedHost := GetAdmittedRouteHost(generated)
+	generated.Status.Ingress[0].Conditions[0].Status = corev1.ConditionTrue
+	admittedHost := GetAdmittedRouteHost(generated)
+
+	testCI := []Test{
+		{"Ingress class", &ingressClass, ing.Spec.IngressClassName},
+		{"Ingress rules grouped by host", 2, len(ing.Spec.Rules)},
+		{"Primary host paths", 2, len(ing.Spec.Rules[0].HTTP.Paths)},
+		{"Primary path port", "8443-tcp", ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name},
+		{"Additional path", "/metrics-admin", ing.Spec.Rules[0].HTTP.Paths[1].Path},
+		{"Additional path port", "admin", ing.Spec.Rules[0].HTTP.Paths[1].Backend.Service.Port.Name},
+		{"Additional path default path type", networkingv1.PathTypePrefix, *ing.Spec.Rules[0].HTTP.Paths[1].PathType},
+		{"Additional host", "admin.example.com", ing.Spec.Rules[1].Host},
+		{"TLS hosts", []string{"my-app.example.com", "admin.example.com"}, ing.Spec.TLS[0].Hosts},
+		{"Route paths", 3, len(paths)},
+		{"Additional path port number", int32(9443), paths[1].PortNumber},
+		{"Additional Route name", name + "-path-2", GetAdditionalRouteName(runtime, 1)},
+		{"Additional Route host", "admin.example.com", route.Spec.Host},
+		{"Additional Route port", intstr.FromString("admin"), route.Spec.Port.TargetPort},
+		{"Route host before admission", "", pendingHost},
+		{"Route host when not admitted", "", rejectedHost},
+		{"Route host when admitted", "my-app-runtime.apps.example.com", admittedHost},
+	}
+	verifyTests(testCI, t)
+}
+
+func TestCustomizeServiceCertificate(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	defer common.SetConfig(nil)
+
+	runtime := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service})
+	defaultCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: name + "-svc-tls-cm"}}
+	defaultIssuer, defaultManaged := GetServiceCertificateIssuer(runtime, "rco")
+	errDefault := CustomizeServiceCertificate(defaultCert, runtime, defaultIssuer)
+
+	configured, _ := common.ParseOpConfig(map[string]string{common.OpConfigCMIssuerName: "corporate-ca", common.OpConfigCMCertRenewBefore: "360h"})
+	common.SetConfig(configured)
+	configIssuer, configManaged := GetServiceCertificateIssuer(runtime, "rco")
+
+	svc := &appstacksv1beta2.RuntimeComponentService{Type: &serviceType, Port: 8443, Certificate: &appstacksv1beta2.RuntimeComponentServiceCertificate{
+		IssuerRef:   &appstacksv1beta2.RuntimeComponentIssuerRef{Name: "team-ca", Kind: "Issuer"},
+		Duration:    &metav1.Duration{Duration: 720 * time.Hour},
+		RenewBefore: &metav1.Duration{Duration: 240 * time.Hour},
+		PrivateKey:  &appstacksv1beta2.RuntimeComponentCertificatePrivateKey{Algorithm: "ECDSA", Size: 384},
+		DNSNames:    []string{"my-app.internal.example.com"},
+	}}
+	runtime = createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: svc, StatefulSet: statefulSet})
+	cert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: name + "-svc-tls-cm"}}
+	specIssuer, _ := GetServiceCertificateIssuer(runtime, "rco")
+	errSpec := CustomizeServiceCertificate(cert, runtime, specIssuer)
+
+	svcHost := name + "." + namespace + ".svc"
+	headlessHost := "*." + name + "-headless." + namespace + ".svc"
+	testCSC := []Test{
+		{"Default issuer", certmanagermetav1.ObjectReference{Name: "rco-ca-issuer"}, defaultIssuer},
+		{"Default issuer managed by the operator", true, defaultManaged},
+		{"Default certificate error", nil, errDefault},
+		{"Default DNS names", []string{svcHost, svcHost + ".cluster.local"}, defaultCert.Spec.DNSNames},
+		{"Default duration", 2160 * time.Hour, defaultCert.Spec.Duration.Duration},
+		{"Default renew before", (*metav1.Duration)(nil), defaultCert.Spec.RenewBefore},
+		{"Default secret name", name + "-svc-tls-cm", defaultCert.Spec.SecretName},
+		{"Issuer from operator configuration", certmanagermetav1.ObjectReference{Name: "corporate-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}, configIssuer},
+		{"Issuer from operator configuration not managed", false, configManaged},
+		{"Certificate error", nil, errSpec},
+		{"Issuer from spec", certmanagermetav1.ObjectReference{Name: "team-ca", Kind: "Issuer", Group: "cert-manager.io"}, cert.Spec.IssuerRef},
+		{"StatefulSet and extra DNS names", []string{svcHost, svcHost + ".cluster.local", headlessHost, headlessHost + ".cluster.local", "my-app.internal.example.com"}, cert.Spec.DNSNames},
+		{"Duration from spec", 720 * time.Hour, cert.Spec.Duration.Duration},
+		{"Renew before from spec", 240 * time.Hour, cert.Spec.RenewBefore.Duration},
+		{"Private key", &certmanagerv1.CertificatePrivateKey{Algorithm: certmanagerv1.ECDSAKeyAlgorithm, Size: 384}, cert.Spec.PrivateKey},
+	}
+	verifyTests(testCSC, t)
+}
+
+func TestNeedsCertificateCleanup(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	defer common.SetConfig(nil)
+
+	disabled, enabled := false, true
+	routeCert := &appstacksv1beta2.RuntimeComponentRouteCertificate{IssuerRef: appstacksv1beta2.RuntimeComponentIssuerRef{Name: "letsencrypt"}}
+	secretRef := "my-app-tls"
+	sharedCA := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{})
+	noTLS := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ManageTLS: &disabled})
+	ownSecret := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{
+		Service: &appstacksv1beta2.RuntimeComponentService{Port: 9443, CertificateSecretRef: &secretRef}})
+	knative := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{CreateKnativeService: &enabled,
+		Expose: &enabled, Route: &appstacksv1beta2.RuntimeComponentRoute{Certificate: routeCert}})
+	routeCertificate := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ManageTLS: &disabled,
+		Expose: &enabled, Route: &appstacksv1beta2.RuntimeComponentRoute{Certificate: routeCert}})
+	notExposed := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{ManageTLS: &disabled,
+		Route: &appstacksv1beta2.RuntimeComponentRoute{Certificate: routeCert}})
+	needsSharedCA := NeedsCertificateCleanup(sharedCA, "rco")
+
+	configured, _ := common.ParseOpConfig(map[string]string{common.OpConfigCMIssuerName: "cluster-ca"})
+	common.SetConfig(configured)
+
+	testNCC := []Test{
+		{"Shared CA issuer", true, needsSharedCA},
+		{"Issuer from operator config", false, NeedsCertificateCleanup(sharedCA, "rco")},
+		{"TLS not managed", false, NeedsCertificateCleanup(noTLS, "rco")},
+		{"Service certificate secret", false, NeedsCertificateCleanup(ownSecret, "rco")},
+		{"Knative service", false, NeedsCertificateCleanup(knative, "rco")},
+		{"Route certificate", true, NeedsCertificateCleanup(routeCertificate, "rco")},
+		{"Route certificate not exposed", false, NeedsCertificateCleanup(notExposed, "rco")},
+	}
+	verifyTests(testNCC, t)
+}
+
+func TestGetTrustedPeers(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	mtls := true
+	newComponent := func(n string, partOf string) appstacksv1beta2.RuntimeComponent {
+		rc := createRuntimeComponent(n, namespace, appstacksv1beta2.RuntimeComponentSpec{
+			Service: &appstacksv1beta2.RuntimeComponentService{Port: 8443, MTLS: &mtls}})
+		if partOf != "" {
+			rc.Labels = map[string]string{"app.kubernetes.io/part-of": partOf}
+		}
+		rc.Status.SetReference(common.StatusReferenceClientCertSecretName, n+"-client-tls-cm")
+		return *rc
+	}
+	// None of the components sets spec.applicationName, and only the instance is initialized
+	orders, payments, inventory, standalone := newComponent("orders", "shop"), newComponent("payments", "shop"), newComponent("inventory", "shop"), newComponent("standalone", "")
+	inventory.Status.SetReference(common.StatusReferenceClientCertSecretName, "")
+	instance := orders.DeepCopy()
+	instance.Initialize()
+	components := []appstacksv1beta2.RuntimeComponent{orders, payments, inventory, standalone}
+
+	standaloneInstance := standalone.DeepCopy()
+	standaloneInstance.Initialize()
+
+	testGTP := []Test{
+		{"Peers of the same application from the part-of label", []string{"payments"}, GetTrustedPeers(instance, components)},
+		{"Component named after itself has no peers", []string{}, GetTrustedPeers(standaloneInstance, components)},
+		{"Listed components are not modified", "", payments.Spec.ApplicationName},
+	}
+	verifyTests(testGTP, t)
+}
+
+func TestParseOpConfig(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	defaults, errDefaults := common.ParseOpConfig(nil)
+	configured, errConfigured := common.ParseOpConfig(map[string]string{
+		common.OpConfigDefaultHostname:       "apps.example.com",
+		common.OpConfigCMCertDuration:        "720h",
+		common.OpConfigCMCertRenewBefore:     "240h",
+		common.OpConfigCMIssuerKind:          "Issuer",
+		common.OpConfigCertExpiryWarningDays: "7",
+		common.OpConfigServerSideApply:       "true",
+	})
+	_, errInvalid := common.ParseOpConfig(map[string]string{
+		common.OpConfigCMCertDuration:        "90d",
+		common.OpConfigCMIssuerKind:          "Venafi",
+		common.OpConfigCertExpiryWarningDays: "-1",
+	})
+	_, errRenewBefore := common.ParseOpConfig(map[string]string{common.OpConfigCMCertRenewBefore: "2160h"})
+
+	testPOC := []Test{
+		{"Default configuration error", nil, errDefaults},
+		{"Default CA duration", 8766 * time.Hour, defaults.CACertDuration},
+		{"Default duration", 2160 * time.Hour, defaults.CertDuration},
+		{"Default renew before", time.Duration(0), defaults.CertRenewBefore},
+		{"Default issuer kind", "ClusterIssuer", defaults.IssuerKind},
+		{"Default expiry warning days", 14, defaults.CertExpiryWarningDays},
+		{"Default server-side apply", false, defaults.ServerSideApply},
+		{"Configuration error", nil, errConfigured},
+		{"Configured default hostname", "apps.example.com", configured.DefaultHostname},
+		{"Configured duration", 720 * time.Hour, configured.CertDuration},
+		{"Configured renew before", 240 * time.Hour, configured.CertRenewBefore},
+		{"Configured issuer kind", "Issuer", configured.IssuerKind},
+		{"Configured expiry warning days", 7, configured.CertExpiryWarningDays},
+		{"Configured server-side apply", true, configured.ServerSideApply},
+		{"Changed keys", []string{common.OpConfigDefaultHostname, common.OpConfigCMCertDuration, common.OpConfigCMCertRenewBefore, common.OpConfigCMIssuerKind, common.OpConfigCertExpiryWarningDays, common.OpConfigServerSideApply}, defaults.ChangedKeys(configured)},
+		{"Unchanged keys", []string{}, defaults.ChangedKeys(defaults)},
+		{"Invalid values are reported", "invalid operator configuration: certManagerCertDuration: \"90d\" is not a positive duration; " +
+			"certManagerIssuerKind: \"Venafi\" is not Issuer or ClusterIssuer; certificateExpiryWarningDays: \"-1\" is not a non-negative number", errInvalid.Error()},
+		{"Renew before longer than the duration", true, errRenewBefore != nil},
+	}
+	verifyTests(testPOC, t)
+}
+
+func TestIsAffectedByConfig(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	expose, knative := true, true
+	runtime := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service})
+	exposed := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service, Expose: &expose})
+	knativeService := createRuntimeComponent(name, namespace, appstacksv1beta2.RuntimeComponentSpec{Service: service, CreateKnativeService: &knative})
+
+	testIABC := []Test{
+		{"Default hostname of an exposed component", true, IsAffectedByConfig(exposed, []string{common.OpConfigDefaultHostname})},
+		{"Default hostname of a component that is not exposed", false, IsAffectedByConfig(runtime, []string{common.OpConfigDefaultHostname})},
+		{"Certificate duration of a component", true, IsAffectedByConfig(runtime, []string{common.OpConfigCMCertDuration})},
+		{"Certificate duration of a Knative component", false, IsAffectedByConfig(knativeService, []string{common.OpConfigCMCertDuration})},
+		{"Server-side apply", true, IsAffectedByConfig(knativeService, []string{common.OpConfigServerSideApply})},
+		{"No changes", false, IsAffectedByConfig(exposed, []string{})},
+	}
+	verifyTests(testIABC, t)
+}
+
+func TestCustomizeGatewayRoutes(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	configured, _ := common.ParseOpConfig(map[string]string{common.OpConfigGatewayName: "shared-gateway", common.OpConfigGatewayNamespace: "gateways"})
+	common.SetConfig(configured)
+	defer common.SetConfig(nil)
+
+	spec := appstacksv1beta2.RuntimeComponentSpec{Service: service, Route: &appstacksv1beta2.RuntimeComponentRoute{Host: "my-app.example.com", Path: "/api", PathType: networkingv1.PathTypeExact}}
+	runtime := createRuntimeComponent(name, namespace, spec)
+	configGateway, configGatewayNamespace, _ := GetParentGateway(runtime)
+
+	runtime.Spec.Route.Gateway = &appstacksv1beta2.RuntimeComponentGateway{Name: "my-gateway", SectionName: "https"}
+	httpRoute := &unstructured.Unstructured{}
+	CustomizeHTTPRoute(httpRoute, runtime)
+	hostnames, _, _ := unstructured.NestedStringSlice(httpRoute.Object, "spec", "hostnames")
+	parentRefs, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "parentRefs")
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	rule := rules[0].(map[string]interface{})
+	match := rule["matches"].([]interface{})[0].(map[string]interface{})["path"]
+	backendRef := rule["backendRefs"].([]interface{})[0].(map[string]interface{})
+
+	SplitHTTPRouteTraffic(httpRoute, runtime, 20)
+	rules, _, _ = unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	splitRefs := rules[0].(map[string]interface{})["backendRefs"].([]interface{})
+
+	passthrough := routev1.TLSTerminationPassthrough
+	runtime.Spec.Route.Termination = &passthrough
+	tlsRoute := &unstructured.Unstructured{}
+	CustomizeTLSRoute(tlsRoute, runtime)
+	tlsRules, _, _ := unstructured.NestedSlice(tlsRoute.Object, "spec", "rules")
+
+	testCGR := []Test{
+		{"Gateway from operator config", "shared-gateway", configGateway},
+		{"Gateway namespace from operator config", "gateways", configGatewayNamespace},
+		{"HTTPRoute kind", HTTPRouteGVK, httpRoute.GroupVersionKind()},
+		{"HTTPRoute hostnames", []string{"my-app.example.com"}, hostnames},
+		{"HTTPRoute parent", map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "my-gateway", "namespace": namespace, "sectionName": "https"}, parentRefs[0]},
+		{"HTTPRoute path match", map[string]interface{}{"type": "Exact", "value": "/api"}, match},
+		{"HTTPRoute backend", name, backendRef["name"]},
+		{"HTTPRoute backend port", int64(service.Port), backendRef["port"]},
+		{"HTTPRoute stable weight", int64(80), splitRefs[0].(map[string]interface{})["weight"]},
+		{"HTTPRoute canary backend", name + "-canary", splitRefs[1].(map[string]interface{})["name"]},
+		{"HTTPRoute canary weight", int64(20), splitRefs[1].(map[string]interface{})["weight"]},
+		{"Passthrough uses TLSRoute", true, UseTLSRoute(runtime)},
+		{"TLSRoute kind", TLSRouteGVK, tlsRoute.GroupVersionKind()},
+		{"TLSRoute rules", 1, len(tlsRules)},
+	}
+	verifyTests(testCGR, t)
+}
+
+func TestConvertV1Beta1RuntimeComponent(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	old := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "app.stacks/v1beta1",
+		"kind":       "RuntimeComponent",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"anno": "value",
+			},
+		},
+		"spec": map[string]interface{}{
+			"applicationImage":    appImage,
+			"version":             "1.0.0",
+			"architecture":        []interface{}{"ppc64le"},
+			"livenessProbe":       map[string]interface{}{"initialDelaySeconds": int64(10)},
+			"readinessProbe":      map[string]interface{}{"initialDelaySeconds": int64(5)},
+			"resourceConstraints": map[string]interface{}{"limits": map[string]interface{}{"cpu": "1"}},
+			"storage":             map[string]interface{}{"size": "10Mi", "mountPath": "/mnt/data"},
+			"createAppDefinition": true,
+			"stack":               "java-microprofile",
+			"stackVersion":        "",
+			"service": map[string]interface{}{
+				"port":     int64(9080),
+				"expose":   true,
+				"consumes": []interface{}{map[string]interface{}{"name": "db"}},
+			},
+		},
+	}}
+
+	rc, dropped, err := ConvertV1Beta1RuntimeComponent(old)
+
+	testCV := []Test{
+		{"Conversion error", nil, err},
+		{"Name", name, rc.Name},
+		{"Namespace", namespace, rc.Namespace},
+		{"Annotations", map[string]string{"anno": "value", MigratedFromAnnotation: "app.stacks/v1beta1"}, rc.Annotations},
+		{"Application image", appImage, rc.Spec.ApplicationImage},
+		{"version to applicationVersion", "1.0.0", rc.Spec.ApplicationVersion},
+		{"architecture to affinity.architecture", arch, rc.Spec.Affinity.Architecture},
+		{"livenessProbe to probes.liveness", int32(10), rc.Spec.Probes.Liveness.InitialDelaySeconds},
+		{"readinessProbe to probes.readiness", int32(5), rc.Spec.Probes.Readiness.InitialDelaySeconds},
+		{"resourceConstraints to resources", resource.MustParse("1"), rc.Spec.Resources.Limits[corev1.ResourceCPU]},
+		{"storage to statefulSet.storage", "10Mi", rc.Spec.StatefulSet.Storage.Size},
+		{"Service port", int32(9080), rc.Spec.Service.Port},
+		{"Dropped fields", []string{".spec.createAppDefinition", ".spec.service.consumes", ".spec.service.expose", ".spec.stack"}, dropped},
+	}
+	verifyTests(testCV, t)
+}
+
 func TestCustomizeServiceMonitor(t *testing.T) {
 
 	logger := zap.New()